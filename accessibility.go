@@ -0,0 +1,217 @@
+package rui
+
+import (
+	"strings"
+)
+
+// Constants for accessibility (ARIA) properties of a view
+const (
+	// Role is the constant for "role" property tag.
+	//
+	// Used by View.
+	// Specifies the ARIA role of the view (e.g. "button", "dialog", "status", "navigation").
+	// If the property is not set, the default role of the view is used (e.g. "listbox" for ListView).
+	//
+	// Supported types: string.
+	Role PropertyName = "role"
+
+	// AriaLabel is the constant for "aria-label" property tag.
+	//
+	// Used by View.
+	// Specifies the accessible name of the view. The text is translated to the session language
+	// unless the "not-translate" property is true.
+	//
+	// Supported types: string.
+	AriaLabel PropertyName = "aria-label"
+
+	// AriaLabelledBy is the constant for "aria-labelledby" property tag.
+	//
+	// Used by View.
+	// Specifies the list of view IDs (separated by spaces) whose text is the accessible name of the view.
+	// The views are searched among the ancestors of the view and their children.
+	//
+	// Supported types: string.
+	AriaLabelledBy PropertyName = "aria-labelledby"
+
+	// AriaDescribedBy is the constant for "aria-describedby" property tag.
+	//
+	// Used by View.
+	// Specifies the list of view IDs (separated by spaces) whose text is the accessible description of the view.
+	// The views are searched among the ancestors of the view and their children.
+	//
+	// Supported types: string.
+	AriaDescribedBy PropertyName = "aria-describedby"
+
+	// AriaLive is the constant for "aria-live" property tag.
+	//
+	// Used by View.
+	// Turns the view into a live region: screen readers announce changes of its content.
+	// See also Session.Announce.
+	//
+	// Supported types: int, string.
+	//
+	// Values:
+	//   - 0 (AriaLiveOff) or "off" - Changes are not announced.
+	//   - 1 (AriaLivePolite) or "polite" - Changes are announced when the user is idle.
+	//   - 2 (AriaLiveAssertive) or "assertive" - Changes are announced immediately.
+	AriaLive PropertyName = "aria-live"
+
+	// AriaHidden is the constant for "aria-hidden" property tag.
+	//
+	// Used by View.
+	// Hides the view and its children from assistive technologies.
+	//
+	// Supported types: bool, int, string.
+	//
+	// Values:
+	//   - true, 1, "true", "yes", "on", or "1" - The view is hidden from assistive technologies.
+	//   - false, 0, "false", "no", "off", or "0" - The view is exposed to assistive technologies.
+	AriaHidden PropertyName = "aria-hidden"
+
+	// AriaModal is the constant for "aria-modal" property tag.
+	//
+	// Used by View.
+	// Indicates that the view is modal (used together with the "dialog" role). Popup sets it by default.
+	//
+	// Supported types: bool, int, string.
+	AriaModal PropertyName = "aria-modal"
+)
+
+// Constants which represent values of the "aria-live" property
+const (
+	// AriaLiveOff - changes of the live region are not announced
+	AriaLiveOff = 0
+
+	// AriaLivePolite - changes of the live region are announced when the user is idle
+	AriaLivePolite = 1
+
+	// AriaLiveAssertive - changes of the live region are announced immediately
+	AriaLiveAssertive = 2
+)
+
+var ariaProperties = []PropertyName{
+	Role, AriaLabel, AriaLabelledBy, AriaDescribedBy, AriaLive, AriaHidden, AriaModal,
+}
+
+// GetRole returns the ARIA role of the subview: the "role" property value or the default role of the view.
+//
+// The second argument (subviewID) specifies the path to the child element whose value needs to be returned.
+// If it is not specified then a value from the first argument (view) is returned.
+func GetRole(view View, subviewID ...string) string {
+	if view = getSubview(view, subviewID); view != nil {
+		if role, ok := stringProperty(view, Role, view.Session()); ok && role != "" {
+			return role
+		}
+		return view.htmlRole()
+	}
+	return ""
+}
+
+// GetAriaLabel returns the accessible name of the subview ("aria-label" property value).
+//
+// The second argument (subviewID) specifies the path to the child element whose value needs to be returned.
+// If it is not specified then a value from the first argument (view) is returned.
+func GetAriaLabel(view View, subviewID ...string) string {
+	if view = getSubview(view, subviewID); view != nil {
+		if label, ok := stringProperty(view, AriaLabel, view.Session()); ok {
+			return label
+		}
+	}
+	return ""
+}
+
+// GetAriaLive returns the "aria-live" property value of the subview: AriaLiveOff (0), AriaLivePolite (1),
+// or AriaLiveAssertive (2).
+//
+// The second argument (subviewID) specifies the path to the child element whose value needs to be returned.
+// If it is not specified then a value from the first argument (view) is returned.
+func GetAriaLive(view View, subviewID ...string) int {
+	return enumStyledProperty(view, subviewID, AriaLive, AriaLiveOff, false)
+}
+
+// ariaViewIDs converts the list of view IDs to the list of HTML IDs. The views are searched starting from
+// the top ancestor of the view. Unknown IDs are written as is.
+func ariaViewIDs(view View, ids string) string {
+	root := view
+	for parent := root.Parent(); parent != nil; parent = parent.Parent() {
+		root = parent
+	}
+
+	container, _ := root.(ParentView)
+	result := []string{}
+	for _, id := range strings.Fields(ids) {
+		if root.ID() == id {
+			result = append(result, root.htmlID())
+		} else if container == nil {
+			result = append(result, id)
+		} else if target := viewByID(container, id); target != nil {
+			result = append(result, target.htmlID())
+		} else {
+			result = append(result, id)
+		}
+	}
+	return strings.Join(result, " ")
+}
+
+// ariaAttribute returns the value of the HTML attribute which corresponds to the ARIA property
+func ariaAttribute(view View, tag PropertyName) string {
+	session := view.Session()
+	switch tag {
+	case Role:
+		return GetRole(view)
+
+	case AriaLabel:
+		label := GetAriaLabel(view)
+		if label != "" && !GetNotTranslate(view) {
+			label, _ = session.GetString(label)
+		}
+		return label
+
+	case AriaLabelledBy, AriaDescribedBy:
+		if ids, ok := stringProperty(view, tag, session); ok && ids != "" {
+			return ariaViewIDs(view, ids)
+		}
+
+	case AriaLive:
+		if value, ok := enumProperty(view, AriaLive, session, AriaLiveOff); ok {
+			return enumProperties[AriaLive].values[value]
+		}
+
+	case AriaHidden, AriaModal:
+		if value, ok := boolProperty(view, tag, session); ok && value {
+			return "true"
+		}
+	}
+	return ""
+}
+
+func ariaHtml(view View, buffer *strings.Builder) {
+	for _, tag := range ariaProperties {
+		if value := ariaAttribute(view, tag); value != "" {
+			buffer.WriteString(string(tag))
+			buffer.WriteString(`="`)
+			buffer.WriteString(textToHtml(value))
+			buffer.WriteString(`" `)
+		}
+	}
+}
+
+func updateAriaProperty(view View, tag PropertyName) {
+	session := view.Session()
+	if self := session.viewByHTMLID(view.htmlID()); self != nil {
+		// the default role is defined by the final view type rather than by the embedded viewData
+		view = self
+	}
+	if value := ariaAttribute(view, tag); value != "" {
+		session.updateProperty(view.htmlID(), string(tag), value)
+	} else {
+		session.removeProperty(view.htmlID(), string(tag))
+	}
+}
+
+func (session *sessionData) Announce(text string, assertive bool) {
+	if text != "" && !session.ignoreViewUpdates() {
+		text, _ = session.GetString(text)
+		session.bridge.callFunc("announce", text, assertive)
+	}
+}
@@ -0,0 +1,43 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAriaProperties(t *testing.T) {
+	createTestLog(t, false)
+
+	session := newSession(nil, 0, "", nil).(*sessionData)
+	label := NewTextView(session, Params{ID: "label", Text: "Name"})
+	edit := NewEditView(session, Params{
+		ID:             "edit",
+		AriaLabelledBy: "label",
+		AriaLive:       "polite",
+		AriaHidden:     true,
+	})
+	session.rootView = NewColumnLayout(session, Params{Content: []View{label, edit}})
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	viewHTML(edit, buffer, "")
+	html := buffer.String()
+	for _, attr := range []string{
+		`aria-labelledby="` + label.htmlID() + `"`,
+		`aria-live="polite"`,
+		`aria-hidden="true"`,
+	} {
+		if !strings.Contains(html, attr) {
+			t.Errorf(`%s is not found in "%s"`, attr, html)
+		}
+	}
+
+	list := NewListView(session, Params{Items: []string{"a", "b"}, Current: 1})
+	if role := GetRole(list); role != "listbox" {
+		t.Errorf(`GetRole(list) = "%s", expected "listbox"`, role)
+	}
+	list.Set(Role, "menu")
+	if role := GetRole(list); role != "menu" {
+		t.Errorf(`GetRole(list) = "%s", expected "menu"`, role)
+	}
+}
@@ -968,8 +968,8 @@ func (view *viewData) SetAnimated(tag PropertyName, value any, animation Animati
 	htmlID := view.htmlID()
 	session.startUpdateScript(htmlID)
 
-	session.updateProperty(htmlID, "ontransitionend", "transitionEndEvent(this, event)")
-	session.updateProperty(htmlID, "ontransitioncancel", "transitionCancelEvent(this, event)")
+	session.updateProperty(htmlID, "data-ontransitionend", "transitionEndEvent(this, event)")
+	session.updateProperty(htmlID, "data-ontransitioncancel", "transitionCancelEvent(this, event)")
 
 	transitions := getTransitionProperty(view)
 	var prevAnimation AnimationProperty = nil
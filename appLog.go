@@ -1,16 +1,27 @@
 package rui
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"runtime"
+	"strings"
+	"sync"
+	"time"
 )
 
 // ProtocolInDebugLog If it is set to true, then the protocol of the exchange between
-// clients and the server is displayed in the debug log
+// clients and the server is displayed in the debug log. If the logger is set by SetLogger then
+// the protocol is logged with LevelProtocol (or with the debug level if the logger ignores LevelProtocol)
 var ProtocolInDebugLog = false
 
+// LevelProtocol is the slog level of the messages of the protocol of the exchange between clients and the server.
+// The messages are logged if the logger set by SetLogger is enabled for this level or ProtocolInDebugLog is true
+const LevelProtocol = slog.LevelDebug - 4
+
 var debugLogFunc func(string) = debugLog
 var errorLogFunc func(string) = errorLog
+var logger *slog.Logger = nil
 
 // SetDebugLog sets a function for outputting debug info.
 // The default value is nil (debug info is ignored)
@@ -24,53 +35,217 @@ func SetErrorLog(f func(string)) {
 	errorLogFunc = f
 }
 
+// SetLogger sets the structured logger which is used by the library instead of the functions
+// set by SetDebugLog and SetErrorLog. The messages of a session carry the "session" and "remote" attributes,
+// the event messages carry the "command" and "view" attributes. If the logger is enabled for the debug level
+// then the errors carry the "stack" attribute. Pass nil to return to SetDebugLog and SetErrorLog functions
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
+// Logger returns the logger set by SetLogger. If it is not set then the returned logger writes
+// to the functions set by SetDebugLog and SetErrorLog
+func Logger() *slog.Logger {
+	if logger != nil {
+		return logger
+	}
+	return slog.New(callbackLogHandler{})
+}
+
 // DebugLog print the text to the debug log
 func DebugLog(text string) {
-	if debugLogFunc != nil {
+	if logger != nil {
+		logger.Debug(text)
+	} else if debugLogFunc != nil {
 		debugLogFunc(text)
 	}
 }
 
 // DebugLogF print the text to the debug log
 func DebugLogF(format string, a ...any) {
-	if debugLogFunc != nil {
-		debugLogFunc(fmt.Sprintf(format, a...))
+	if logger != nil || debugLogFunc != nil {
+		DebugLog(fmt.Sprintf(format, a...))
 	}
 }
 
 var lastError = ""
+var lastErrorMutex sync.Mutex
+
+func setLastError(text string) {
+	lastErrorMutex.Lock()
+	lastError = text
+	lastErrorMutex.Unlock()
+}
 
 // ErrorLog print the text to the error log
 func ErrorLog(text string) {
-	lastError = text
-	if errorLogFunc != nil {
-		errorLogFunc(text)
-		errorStack()
-	}
+	setLastError(text)
+	logError(text)
 }
 
 // ErrorLogF print the text to the error log
 func ErrorLogF(format string, a ...any) {
-	lastError = fmt.Sprintf(format, a...)
-	if errorLogFunc != nil {
-		errorLogFunc(lastError)
-		errorStack()
-	}
+	text := fmt.Sprintf(format, a...)
+	setLastError(text)
+	logError(text)
 }
 
-// LastError returns the last error text
+// LastError returns the last error text of all sessions. Use Session.LastError to get the last error of a session
 func LastError() string {
+	lastErrorMutex.Lock()
+	defer lastErrorMutex.Unlock()
 	return lastError
 }
 
-func errorStack() {
-	if errorLogFunc != nil {
-		skip := 2
-		_, file, line, ok := runtime.Caller(skip)
-		for ok {
-			errorLogFunc(fmt.Sprintf("\t%s: line %d", file, line))
-			skip++
-			_, file, line, ok = runtime.Caller(skip)
+// logError writes the error with the slog arguments to the logger (if SetLogger was called)
+// or to the error log function. It must be called directly by the function which is called by the source of the error
+func logError(text string, args ...any) {
+	if logger != nil {
+		if logger.Enabled(context.Background(), slog.LevelDebug) {
+			args = append(args, slog.String("stack", strings.Join(errorStack(3), "\n")))
+		}
+		logger.Error(text, args...)
+
+	} else if errorLogFunc != nil {
+		errorLogFunc(text + logAttrsText(args))
+		for _, line := range errorStack(3) {
+			errorLogFunc(line)
+		}
+	}
+}
+
+func errorStack(skip int) []string {
+	result := []string{}
+	_, file, line, ok := runtime.Caller(skip)
+	for ok {
+		result = append(result, fmt.Sprintf("\t%s: line %d", file, line))
+		skip++
+		_, file, line, ok = runtime.Caller(skip)
+	}
+	return result
+}
+
+// debugLogEnabled returns true if the debug messages are logged
+func debugLogEnabled() bool {
+	if logger != nil {
+		return logger.Enabled(context.Background(), slog.LevelDebug)
+	}
+	return debugLogFunc != nil
+}
+
+// protocolLogEnabled returns true if the protocol messages are logged (see ProtocolInDebugLog and LevelProtocol)
+func protocolLogEnabled() bool {
+	return ProtocolInDebugLog || (logger != nil && logger.Enabled(context.Background(), LevelProtocol))
+}
+
+// protocolLog writes the protocol message. The call must be guarded by protocolLogEnabled
+func protocolLog(text string, args ...any) {
+	if logger != nil {
+		level := LevelProtocol
+		if !logger.Enabled(context.Background(), level) {
+			level = slog.LevelDebug
 		}
+		logger.Log(context.Background(), level, text, args...)
+	} else if debugLogFunc != nil {
+		debugLogFunc(text + logAttrsText(args))
+	}
+}
+
+// logAttrsText returns the " key=value" text of the slog arguments
+func logAttrsText(args []any) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	record := slog.NewRecord(time.Time{}, 0, "", 0)
+	record.Add(args...)
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		writeLogAttr(buffer, "", attr)
+		return true
+	})
+	return buffer.String()
+}
+
+func writeLogAttr(buffer *strings.Builder, prefix string, attr slog.Attr) {
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, item := range value.Group() {
+			writeLogAttr(buffer, prefix, item)
+		}
+		return
+	}
+
+	buffer.WriteRune(' ')
+	buffer.WriteString(prefix)
+	buffer.WriteString(attr.Key)
+	buffer.WriteRune('=')
+	buffer.WriteString(value.String())
+}
+
+// callbackLogHandler is the slog handler which writes to the functions set by SetDebugLog and SetErrorLog
+type callbackLogHandler struct {
+	attrs  string
+	prefix string
+}
+
+func (handler callbackLogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	switch {
+	case level >= slog.LevelError:
+		return errorLogFunc != nil
+
+	case level < slog.LevelDebug:
+		return ProtocolInDebugLog && debugLogFunc != nil
+	}
+	return debugLogFunc != nil
+}
+
+func (handler callbackLogHandler) Handle(_ context.Context, record slog.Record) error {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteString(record.Message)
+	buffer.WriteString(handler.attrs)
+	record.Attrs(func(attr slog.Attr) bool {
+		writeLogAttr(buffer, handler.prefix, attr)
+		return true
+	})
+
+	if record.Level >= slog.LevelError {
+		if errorLogFunc != nil {
+			errorLogFunc(buffer.String())
+		}
+	} else if debugLogFunc != nil {
+		debugLogFunc(buffer.String())
+	}
+	return nil
+}
+
+func (handler callbackLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteString(handler.attrs)
+	for _, attr := range attrs {
+		writeLogAttr(buffer, handler.prefix, attr)
+	}
+	handler.attrs = buffer.String()
+	return handler
+}
+
+func (handler callbackLogHandler) WithGroup(name string) slog.Handler {
+	if name != "" {
+		handler.prefix += name + "."
 	}
+	return handler
 }
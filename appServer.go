@@ -4,7 +4,10 @@ package rui
 
 import (
 	"context"
+	cryptoRand "crypto/rand"
 	_ "embed"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -15,8 +18,11 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"golang.org/x/crypto/acme/autocert"
 )
 
@@ -37,26 +43,178 @@ func errorLog(text string) {
 type sessionInfo struct {
 	session  Session
 	response chan string
+	host     string
+	limiter  *eventRateLimiter
+}
+
+type prerenderedSession struct {
+	session Session
+	host    string
+	timer   *time.Timer
 }
 
 type application struct {
 	server            *http.Server
+	redirectServer    *http.Server
 	params            AppParams
 	createContentFunc func(Session) SessionContent
 	sessions          map[int]sessionInfo
+	sessionsMutex     sync.RWMutex
 	usedSessionIds    []int
+	prerendered       map[int]prerenderedSession
+	prerenderMutex    sync.Mutex
+	metrics           *appMetrics
+	shuttingDown      atomic.Bool
 }
 
-func (app *application) getStartPage() string {
+// prerenderTimeout is the time during which a prerendered session waits for the connection of the client
+var prerenderTimeout = time.Minute
+
+// maxPrerenderedSessions is the maximal number of prerendered sessions waiting for the connection.
+// If the limit is reached then the start page is sent without prerendering
+const maxPrerenderedSessions = 1000
+
+func (app *application) getStartPage(req *http.Request, nonce string, user Principal, clientID string) string {
 	buffer := allocStringBuilder()
 	defer freeStringBuilder(buffer)
 
+	sessionID := app.nextSessionID()
+	var session Session
+	if app.params.Prerender {
+		session = app.prerender(sessionID, req, user, clientID)
+	}
+
 	buffer.WriteString("<!DOCTYPE html>\n<html>\n")
-	getStartPage(buffer, app.nextSessionID(), app.params)
+	getStartPage(buffer, sessionID, app.params, session, nonce)
 	buffer.WriteString("\n</html>")
 	return buffer.String()
 }
 
+// prerender creates the session and its root view before the client is connected
+func (app *application) prerender(sessionID int, req *http.Request, user Principal, clientID string) Session {
+	if app.createContentFunc == nil {
+		return nil
+	}
+
+	host := remoteHost(req.RemoteAddr)
+	if !app.canPrerender(host) {
+		return nil
+	}
+
+	params := NewDataObject("session-info")
+	if languages := acceptLanguages(req.Header.Get("Accept-Language")); len(languages) > 0 {
+		params.SetPropertyValue("language", languages[0])
+		params.SetPropertyValue("languages", strings.Join(languages, ","))
+	}
+	params.SetPropertyValue("user-agent", req.UserAgent())
+
+	session := newSession(app, sessionID, "", params)
+	session.setUser(user)
+	session.setClientID(clientID)
+	if !session.setContent(app.createContentFunc(session)) {
+		return nil
+	}
+
+	app.prerenderMutex.Lock()
+	defer app.prerenderMutex.Unlock()
+
+	if app.prerendered == nil {
+		app.prerendered = map[int]prerenderedSession{}
+	}
+	if len(app.prerendered) >= maxPrerenderedSessions {
+		return nil
+	}
+
+	app.prerendered[sessionID] = prerenderedSession{
+		session: session,
+		host:    host,
+		timer: time.AfterFunc(prerenderTimeout, func() {
+			app.takePrerendered(sessionID)
+		}),
+	}
+	return session
+}
+
+// canPrerender returns false if the limit of the prerendered sessions is reached or the limit
+// of sessions (AppParams.MaxSessionsPerAddress) is reached for the remote address
+func (app *application) canPrerender(host string) bool {
+	if app.tooManySessions(host) {
+		return false
+	}
+
+	app.prerenderMutex.Lock()
+	defer app.prerenderMutex.Unlock()
+
+	if len(app.prerendered) >= maxPrerenderedSessions {
+		return false
+	}
+
+	if limit := app.params.MaxSessionsPerAddress; limit > 0 {
+		count := 0
+		for _, info := range app.prerendered {
+			if info.host == host {
+				if count++; count >= limit {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// takePrerendered returns the prerendered session with the given id (or nil) and removes it from the waiting list
+func (app *application) takePrerendered(sessionID int) Session {
+	app.prerenderMutex.Lock()
+	defer app.prerenderMutex.Unlock()
+
+	if info, ok := app.prerendered[sessionID]; ok {
+		info.timer.Stop()
+		delete(app.prerendered, sessionID)
+		return info.session
+	}
+	return nil
+}
+
+// acceptLanguages returns the list of languages of the "Accept-Language" header in the order of preference
+func acceptLanguages(header string) []string {
+	type language struct {
+		name    string
+		quality float64
+	}
+
+	list := []language{}
+	for _, item := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(item), ";")
+		if name = strings.TrimSpace(name); name == "" || name == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = f
+			}
+		}
+		list = append(list, language{name: name, quality: quality})
+	}
+
+	slices.SortStableFunc(list, func(a, b language) int {
+		switch {
+		case a.quality > b.quality:
+			return -1
+		case a.quality < b.quality:
+			return 1
+		}
+		return 0
+	})
+
+	result := make([]string, len(list))
+	for i, lang := range list {
+		result[i] = lang.name
+	}
+	return result
+}
+
 func (app *application) Params() AppParams {
 	params := app.params
 	if params.NoSocket {
@@ -66,20 +224,54 @@ func (app *application) Params() AppParams {
 }
 
 func (app *application) Finish() {
-	for _, session := range app.sessions {
-		session.session.close()
-		if session.response != nil {
-			close(session.response)
-			session.response = nil
-		}
+	if err := app.shutdown(); err != nil {
+		ErrorLog(err.Error())
+	}
+}
+
+// shutdown notifies all sessions that the server is going away, waits until the sessions are finished
+// and shuts down the HTTP servers. The total time is limited by AppParams.ShutdownTimeout
+func (app *application) shutdown() error {
+	timeout := app.params.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	if err := app.server.Shutdown(ctx); err != nil {
-		log.Println(err.Error())
+	// a quarter of the time is left for the shutdown of the HTTP servers
+	sessionsCtx, sessionsCancel := context.WithTimeout(ctx, timeout*3/4)
+	defer sessionsCancel()
+
+	app.shuttingDown.Store(true)
+	for _, info := range app.sessionList() {
+		info.session.shutdown(sessionsCtx)
 	}
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+waitSessions:
+	for len(app.sessionList()) > 0 {
+		select {
+		case <-sessionsCtx.Done():
+			ErrorLogF("%d sessions are not finished before the shutdown", len(app.sessionList()))
+			break waitSessions
+
+		case <-ticker.C:
+		}
+	}
+
+	var result error
+	for _, server := range []*http.Server{app.redirectServer, app.server} {
+		if server != nil {
+			if err := server.Shutdown(ctx); err != nil {
+				result = errors.Join(result, err)
+			}
+		}
+	}
+	return result
 }
 
 func (app *application) getCreateContentFunc() func(Session) SessionContent {
@@ -102,20 +294,29 @@ func (app *application) nextSessionID() int {
 }
 
 func (app *application) removeSession(id int) {
-	if info, ok := app.sessions[id]; ok {
+	app.sessionsMutex.Lock()
+	info, ok := app.sessions[id]
+	if ok {
 		if info.response != nil {
 			close(info.response)
 		}
 		delete(app.sessions, id)
 	}
+	app.sessionsMutex.Unlock()
+
+	if ok {
+		app.notifyClientSessions(info.session, false)
+	}
 }
 
 func (app *application) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
-	if ProtocolInDebugLog {
-		DebugLogF("%s %s", req.Method, req.URL.Path)
+	if protocolLogEnabled() {
+		protocolLog(req.Method+" "+req.URL.Path, "remote", req.RemoteAddr)
 	}
 
+	app.writeSecurityHeaders(w, req)
+
 	switch req.Method {
 	case http.MethodPost:
 		if req.URL.Path == "/" {
@@ -125,15 +326,29 @@ func (app *application) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	case http.MethodGet:
 		switch req.URL.Path {
 		case "/":
+			user, ok := app.authenticate(w, req, true)
+			if !ok {
+				return
+			}
+
+			nonce := ""
+			if policy := app.contentSecurityPolicy(); policy != "" {
+				nonce = newNonce()
+				w.Header().Set("Content-Security-Policy", strings.ReplaceAll(policy, "{nonce}", nonce))
+			}
+			clientID := app.startPageClientID(w, req)
 			w.WriteHeader(http.StatusOK)
-			io.WriteString(w, app.getStartPage())
+			io.WriteString(w, app.getStartPage(req, nonce, user, clientID))
 
 		case "/e":
 			app.sseHandler(w, req)
 
 		case "/ws":
-			if bridge := createSocketBridge(w, req); bridge != nil {
-				go app.socketReader(bridge)
+			if user, ok := app.authenticate(w, req, false); ok {
+				if bridge := createSocketBridge(w, req, app.checkOrigin, app.maxMessageSize(), app.params.CompressionLevel); bridge != nil {
+					bridge.metrics = app.metrics
+					go app.socketReader(bridge, user, app.requestClientID(req))
+				}
 			}
 
 		case "/script.js":
@@ -146,20 +361,109 @@ func (app *application) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			io.WriteString(w, "\n")
 			io.WriteString(w, defaultScripts)
 
-		default:
-			filename := req.URL.Path[1:]
-			if size := len(filename); size > 0 && filename[size-1] == '/' {
-				filename = filename[:size-1]
+		case "/metrics", "/healthz":
+			if !app.serveMonitoring(w, req.URL.Path) {
+				app.serveFile(w, req)
 			}
 
-			if !serveResourceFile(filename, w, req) &&
-				!serveDownloadFile(filename, w, req) {
-				w.WriteHeader(http.StatusNotFound)
-			}
+		default:
+			app.serveFile(w, req)
 		}
 	}
 }
 
+// serveFile writes the resource file or the file downloaded by the session (see Session.DownloadFile)
+func (app *application) serveFile(w http.ResponseWriter, req *http.Request) {
+	filename := req.URL.Path[1:]
+	if size := len(filename); size > 0 && filename[size-1] == '/' {
+		filename = filename[:size-1]
+	}
+
+	if serveResourceFile(filename, w, req) {
+		return
+	}
+
+	writer := &countingResponseWriter{ResponseWriter: w}
+	if serveDownloadFile(filename, writer, req) {
+		app.metrics.downloaded(writer.size)
+	} else {
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// authenticate calls AppParams.Authenticate. If the request is rejected then the response is written
+// (the redirect is used only if "startPage" is true) and false is returned
+func (app *application) authenticate(w http.ResponseWriter, req *http.Request, startPage bool) (Principal, bool) {
+	if app.params.Authenticate == nil {
+		return nil, true
+	}
+
+	user, err := app.params.Authenticate(req)
+	if err == nil {
+		return user, true
+	}
+
+	if protocolLogEnabled() {
+		protocolLog(req.Method+" "+req.URL.Path+": "+err.Error(), "remote", req.RemoteAddr)
+	}
+
+	var redirect AuthRedirect
+	if startPage && errors.As(err, &redirect) && redirect.URL != "" {
+		http.Redirect(w, req, redirect.URL, http.StatusFound)
+	} else {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	}
+	return nil, false
+}
+
+// contentSecurityPolicy returns the Content-Security-Policy of the start page (with the "{nonce}" placeholder)
+func (app *application) contentSecurityPolicy() string {
+	policy := strings.TrimSpace(app.params.ContentSecurityPolicy)
+	if app.params.FrameAncestors == "" {
+		return policy
+	}
+
+	for _, directive := range strings.Split(policy, ";") {
+		if name, _, _ := strings.Cut(strings.TrimSpace(directive), " "); strings.EqualFold(name, "frame-ancestors") {
+			return policy
+		}
+	}
+
+	frameAncestors := "frame-ancestors " + app.params.FrameAncestors
+	if policy = strings.TrimSuffix(policy, ";"); policy == "" {
+		return frameAncestors
+	}
+	return policy + "; " + frameAncestors
+}
+
+func (app *application) writeSecurityHeaders(w http.ResponseWriter, req *http.Request) {
+	header := w.Header()
+	for key, value := range app.params.SecurityHeaders {
+		header.Set(key, value)
+	}
+
+	switch app.params.FrameAncestors {
+	case "'none'":
+		header.Set("X-Frame-Options", "DENY")
+
+	case "'self'":
+		header.Set("X-Frame-Options", "SAMEORIGIN")
+	}
+
+	if app.params.StrictTransportSecurity != "" && req.TLS != nil {
+		header.Set("Strict-Transport-Security", app.params.StrictTransportSecurity)
+	}
+}
+
+// newNonce returns the random base64 string which is used as the nonce of the inline blocks of the start page
+func newNonce() string {
+	data := make([]byte, 16)
+	if _, err := cryptoRand.Read(data); err != nil {
+		ErrorLog(err.Error())
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
 /*
 func setSessionIDCookie(w http.ResponseWriter, sessionID int) {
 	cookie := http.Cookie{
@@ -182,15 +486,33 @@ func getSessionIDCookie(req *http.Request) (int, error) {
 
 func (app *application) postHandler(w http.ResponseWriter, req *http.Request) {
 
-	reqBody, err := io.ReadAll(req.Body)
+	if !app.checkOrigin(req) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	body := req.Body
+	if limit := app.maxMessageSize(); limit > 0 {
+		body = http.MaxBytesReader(w, body, limit)
+	}
+
+	reqBody, err := io.ReadAll(body)
 	if err != nil {
-		ErrorLog(err.Error())
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			// the client is not trusted, so its oversized messages are not logged as errors of the server
+			Logger().Warn("The message is too large", "remote", req.RemoteAddr, "limit", maxBytesErr.Limit)
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			ErrorLog(err.Error())
+		}
 		return
 	}
 
 	message := string(reqBody)
-	if ProtocolInDebugLog {
-		DebugLog(message)
+	app.metrics.messageReceived(len(reqBody))
+	if protocolLogEnabled() {
+		protocolLog(message, "remote", req.RemoteAddr)
 	}
 
 	obj, err := ParseDataText(message)
@@ -204,9 +526,30 @@ func (app *application) postHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	user, ok := app.authenticate(w, req, false)
+	if !ok {
+		return
+	}
+
 	var session Session = nil
 	var response chan string = nil
-	if info, ok := app.sessions[sessionID]; ok && info.response != nil {
+	if info, ok := app.sessionInfo(sessionID); ok && info.response != nil {
+		if !samePrincipal(info.session.User(), user) {
+			// the user is changed: the page is reloaded as by the socket "reconnect" command
+			io.WriteString(w, clientCall("reloadPage"))
+			return
+		}
+
+		if !info.limiter.allow() {
+			if app.params.RateLimitPolicy == CloseSessionOnExcess {
+				// the session is finished by its goroutine (see sessionEventHandler)
+				info.session.errorLog("The session is closed: the event rate limit is exceeded")
+				info.session.close()
+			}
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
 		response = info.response
 		session = info.session
 	}
@@ -215,20 +558,26 @@ func (app *application) postHandler(w http.ResponseWriter, req *http.Request) {
 
 	if session == nil {
 		if command != "start-session" {
-			io.WriteString(w, "reloadPage();")
+			io.WriteString(w, clientCall("reloadPage"))
+			return
+		}
+
+		if app.tooManySessions(req.RemoteAddr) {
+			w.WriteHeader(http.StatusTooManyRequests)
 			return
 		}
 
 		events := make(chan DataObject, 1024)
 		bridge := createHttpBridge(req)
+		bridge.metrics = app.metrics
 		response = bridge.response
 
-		session = app.createSession(obj, events, bridge, response)
+		session = app.createSession(obj, events, bridge, response, user, app.requestClientID(req))
 		if session == nil {
 			return
 		}
 
-		go sessionEventHandler(session, events, bridge)
+		go app.sessionEventHandler(session, events, bridge)
 	}
 
 	switch command {
@@ -247,12 +596,14 @@ func (app *application) postHandler(w http.ResponseWriter, req *http.Request) {
 		if !session.handleAnswer(command, obj) {
 			session.addToEventsQueue(obj)
 		} else {
-			io.WriteString(w, "sendNop();")
+			io.WriteString(w, clientCall("sendNop")+"\n")
 		}
 	}
 
+	// the messages are written as the lines of JSON (see runServerMessage in app_scripts.js)
 	io.WriteString(w, <-response)
 	for len(response) > 0 {
+		io.WriteString(w, "\n")
 		io.WriteString(w, <-response)
 	}
 }
@@ -271,6 +622,11 @@ func (app *application) sseHandler(w http.ResponseWriter, req *http.Request) {
 	*/
 }
 
+// clientCall returns the message which calls the client function without arguments
+func clientCall(funcName string) string {
+	return `[["` + funcName + `"]]`
+}
+
 func getSessionID(obj DataObject) (int, bool) {
 	sessionText, ok := obj.PropertyValue("session")
 	if !ok {
@@ -287,8 +643,9 @@ func getSessionID(obj DataObject) (int, bool) {
 	return sessionID, true
 }
 
-func (app *application) socketReader(bridge *wsBridge) {
+func (app *application) socketReader(bridge *wsBridge, user Principal, clientID string) {
 	var session Session
+	var limiter *eventRateLimiter
 	events := make(chan DataObject, 1024)
 
 	for {
@@ -298,9 +655,10 @@ func (app *application) socketReader(bridge *wsBridge) {
 			return
 		}
 
-		if ProtocolInDebugLog {
-			DebugLog("🖥️ → " + message)
+		if protocolLogEnabled() {
+			protocolLog("🖥️ → "+message, "remote", bridge.remoteAddr())
 		}
+		app.metrics.messageReceived(len(message))
 
 		obj, err := ParseDataText(message)
 		if err != nil {
@@ -310,19 +668,30 @@ func (app *application) socketReader(bridge *wsBridge) {
 
 		switch command := obj.Tag(); command {
 		case "start-session":
-			if session = app.createSession(obj, events, bridge, nil); session != nil {
-				go sessionEventHandler(session, events, bridge)
+			if app.tooManySessions(bridge.remoteAddr()) {
+				bridge.closeWithStatus(websocket.CloseTryAgainLater, "too many sessions")
+				return
+			}
+
+			if session = app.createSession(obj, events, bridge, nil, user, clientID); session != nil {
+				if info, ok := app.sessionInfo(session.ID()); ok {
+					limiter = info.limiter
+				}
+				go app.sessionEventHandler(session, events, bridge)
 				events <- obj
 			}
 
 		case "reconnect":
 			session = nil
 			if sessionID, ok := getSessionID(obj); ok {
-				if info, ok := app.sessions[sessionID]; ok {
+				if info, ok := app.sessionInfo(sessionID); ok && samePrincipal(info.session.User(), user) {
 					session = info.session
+					limiter = info.limiter
+					session.setUser(user)
 					session.setBridge(events, bridge)
 
-					go sessionEventHandler(session, events, bridge)
+					app.metrics.reconnected()
+					go app.sessionEventHandler(session, events, bridge)
 					session.onReconnect()
 				} else {
 					DebugLogF("Session #%d not exists", sessionID)
@@ -330,11 +699,32 @@ func (app *application) socketReader(bridge *wsBridge) {
 			}
 
 			if session == nil {
-				bridge.writeMessage("reloadPage();")
+				bridge.callFunc("reloadPage")
 				return
 			}
 
 		default:
+			if session == nil {
+				continue
+			}
+
+			if !limiter.allow() {
+				switch app.params.RateLimitPolicy {
+				case DisconnectOnExcess:
+					session.errorLog("The session is disconnected: the event rate limit is exceeded")
+					bridge.closeWithStatus(websocket.ClosePolicyViolation, "event rate limit exceeded")
+					events <- NewDataObject("disconnect")
+					return
+
+				case CloseSessionOnExcess:
+					session.errorLog("The session is closed: the event rate limit is exceeded")
+					bridge.closeWithStatus(websocket.ClosePolicyViolation, "event rate limit exceeded")
+					events <- NewDataObject("session-close")
+					return
+				}
+				continue
+			}
+
 			if !session.handleAnswer(command, obj) {
 				events <- obj
 			}
@@ -342,12 +732,13 @@ func (app *application) socketReader(bridge *wsBridge) {
 	}
 }
 
-func sessionEventHandler(session Session, events chan DataObject, bridge bridge) {
+func (app *application) sessionEventHandler(session Session, events chan DataObject, bridge bridge) {
 	for {
 		data := <-events
 
 		switch command := data.Tag(); command {
 		case "disconnect":
+			app.metrics.disconnected()
 			session.setBridge(nil, nil)
 			session.onDisconnect()
 			return
@@ -361,43 +752,112 @@ func sessionEventHandler(session Session, events chan DataObject, bridge bridge)
 		case "nop":
 			session.sendResponse()
 
+		case "session-call":
+			bridge.startBatch()
+			session.handleEvent(command, data)
+
 		default:
+			bridge.startBatch()
+			app.metrics.event(command)
+			if command == "fileLoaded" && app.metrics != nil {
+				app.metrics.uploaded(receivedFileSize(data))
+			}
 			session.handleEvent(command, data)
 		}
 	}
 }
 
 func (app *application) createSession(params DataObject, events chan DataObject,
-	bridge bridge, response chan string) Session {
+	bridge bridge, response chan string, user Principal, clientID string) Session {
 
 	sessionID, ok := getSessionID(params)
 	if !ok || app.createContentFunc == nil {
 		return nil
 	}
 
-	session := newSession(app, sessionID, "", params)
+	session := app.takePrerendered(sessionID)
+	if session == nil || !samePrincipal(session.User(), user) {
+		session = newSession(app, sessionID, "", params)
+	}
+	session.setUser(user)
+	session.setClientID(clientID)
 	session.setBridge(events, bridge)
 
+	host := ""
+	if bridge != nil {
+		host = remoteHost(bridge.remoteAddr())
+	}
+
+	app.metrics.connected()
+
+	app.sessionsMutex.Lock()
 	app.sessions[sessionID] = sessionInfo{
 		session:  session,
 		response: response,
+		host:     host,
+		limiter:  newEventRateLimiter(app.params.EventRateLimit, app.params.EventRateBurst),
 	}
+	app.sessionsMutex.Unlock()
 
+	session.setConnectionState(SessionConnected)
+	app.notifyClientSessions(session, true)
 	return session
 }
 
-var apps = []*application{}
+var (
+	apps      = []*application{}
+	appsMutex sync.Mutex
+)
+
+// runningApps returns the copy of the list of running applications
+func runningApps() []*application {
+	appsMutex.Lock()
+	defer appsMutex.Unlock()
+	return slices.Clone(apps)
+}
 
-// StartApp - create the new application and start it
+// StartApp - create the new application and start it. The function returns after the application is finished
+// by FinishApp. If the server cannot be started then the error is logged and the program exits.
+// Use StartAppContext to handle the errors and to finish the application by a context
 func StartApp(addr string, createContentFunc func(Session) SessionContent, params AppParams) {
+	if err := StartAppContext(context.Background(), addr, createContentFunc, params); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// StartAppContext creates the new application and runs it until the context is canceled or FinishApp is called.
+// When the context is canceled, all sessions are notified (the client shows the banner with
+// AppParams.ShutdownMessage, SessionShutdownListener and SessionFinishListener of the session content are called)
+// and the HTTP server is shut down within AppParams.ShutdownTimeout.
+// The function returns nil if the application is finished normally, otherwise it returns the error
+// of the server start or of the shutdown
+func StartAppContext(ctx context.Context, addr string, createContentFunc func(Session) SessionContent, params AppParams) error {
 	resources.scanDefaultResourcePath()
 
+	app := newApplication(createContentFunc, params)
+	mux := http.NewServeMux()
+	mux.Handle("/", app)
+	return app.listenAndServe(ctx, addr, mux)
+}
+
+func newApplication(createContentFunc func(Session) SessionContent, params AppParams) *application {
 	app := new(application)
 	app.params = params
 	app.sessions = map[int]sessionInfo{}
 	app.createContentFunc = createContentFunc
+	if params.Metrics {
+		app.metrics = newAppMetrics()
+	}
+
+	appsMutex.Lock()
 	apps = append(apps, app)
+	appsMutex.Unlock()
+	return app
+}
 
+// listenAndServe runs the HTTP server with the handler until the context is canceled or the application is finished
+func (app *application) listenAndServe(ctx context.Context, addr string, handler http.Handler) error {
+	params := app.params
 	redirectAddr := ""
 	https := params.AutoCertDomain != "" || (params.CertFile != "" && params.KeyFile != "")
 
@@ -412,49 +872,83 @@ func StartApp(addr string, createContentFunc func(Session) SessionContent, param
 		}
 	}
 
+	errChan := make(chan error, 2)
 	serverRun := func(err error) {
-		if err != nil {
-			if err == http.ErrServerClosed {
-				log.Println(err)
-			} else {
-				log.Fatal(err)
-			}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errChan <- err
+		} else {
+			errChan <- nil
 		}
 	}
 
+	app.server = &http.Server{Addr: addr, Handler: handler}
+
 	if https {
 		if params.Redirect80 {
 			redirectTLS := func(w http.ResponseWriter, r *http.Request) {
 				http.Redirect(w, r, "https://"+addr+r.RequestURI, http.StatusMovedPermanently)
 			}
 
+			app.redirectServer = &http.Server{Addr: redirectAddr, Handler: http.HandlerFunc(redirectTLS)}
 			go func() {
-				serverRun(http.ListenAndServe(redirectAddr, http.HandlerFunc(redirectTLS)))
+				if err := app.redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					errChan <- err
+				}
 			}()
 		}
 
 		if params.AutoCertDomain != "" {
-			mux := http.NewServeMux()
-			mux.Handle("/", app)
-			serverRun(http.Serve(autocert.NewListener(params.AutoCertDomain), mux))
+			go func() {
+				serverRun(app.server.Serve(autocert.NewListener(params.AutoCertDomain)))
+			}()
 		} else {
-			app.server = &http.Server{Addr: addr}
-			http.Handle("/", app)
-			serverRun(app.server.ListenAndServeTLS(params.CertFile, params.KeyFile))
+			go func() {
+				serverRun(app.server.ListenAndServeTLS(params.CertFile, params.KeyFile))
+			}()
 		}
 	} else {
-		app.server = &http.Server{Addr: addr}
-		http.Handle("/", app)
-		serverRun(app.server.ListenAndServe())
+		go func() {
+			serverRun(app.server.ListenAndServe())
+		}()
+	}
+
+	select {
+	case err := <-errChan:
+		// the server failed to start or was shut down by FinishApp
+		if err != nil {
+			app.removeFromApps()
+			if app.redirectServer != nil {
+				app.redirectServer.Close()
+			}
+			app.server.Close()
+		}
+		return err
+
+	case <-ctx.Done():
+		app.removeFromApps()
+		return app.shutdown()
 	}
 }
 
+func (app *application) removeFromApps() {
+	appsMutex.Lock()
+	defer appsMutex.Unlock()
+
+	apps = slices.DeleteFunc(apps, func(item *application) bool {
+		return item == app
+	})
+}
+
 // FinishApp finishes application
 func FinishApp() {
-	for _, app := range apps {
+	appsMutex.Lock()
+	list := apps
+	apps = []*application{}
+	appsMutex.Unlock()
+
+	for _, app := range list {
 		app.Finish()
 	}
-	apps = []*application{}
 }
 
 // OpenBrowser open browser with specific URL locally. Useful for applications which run on local machine
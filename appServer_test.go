@@ -0,0 +1,220 @@
+package rui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrerender(t *testing.T) {
+	languages := acceptLanguages("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.95, *;q=0.5")
+	if text := strings.Join(languages, ","); text != "fr-CH,de,fr,en" {
+		t.Errorf(`acceptLanguages = "%s"`, text)
+	}
+
+	app := createTestApp(t, AppParams{Prerender: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+	page := app.getStartPage(req, "", nil, "")
+	if !strings.Contains(page, `<div class="ruiRoot" id="ruiRootView"><div id="id000001"`) ||
+		!strings.Contains(page, "Landing page") {
+		t.Errorf("the root view is not prerendered:\n%s", page)
+	}
+
+	_, style, _ := strings.Cut(page, "<style>")
+	style, _, _ = strings.Cut(style, "</style>")
+	if !strings.Contains(style, ".ruiView {") || strings.Contains(style, `\n`) || strings.Contains(style, `\t`) {
+		t.Errorf("invalid prerendered styles:\n%s", style)
+	}
+
+	if len(app.prerendered) != 1 {
+		t.Fatalf("%d prerendered sessions, expected 1", len(app.prerendered))
+	}
+	for id, info := range app.prerendered {
+		if info.session.Language() != "de-DE" {
+			t.Errorf(`language = "%s", expected "de-DE"`, info.session.Language())
+		}
+		if session := app.takePrerendered(id); session != info.session {
+			t.Error("takePrerendered returns invalid session")
+		}
+		if session := app.takePrerendered(id); session != nil {
+			t.Error("the prerendered session is not removed")
+		}
+	}
+}
+
+func TestPrerenderLimits(t *testing.T) {
+	createTestLog(t, true)
+
+	app := createTestApp(t, AppParams{Prerender: true, MaxSessionsPerAddress: 2})
+	request := func(address string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = address
+		return app.getStartPage(req, "", nil, "")
+	}
+
+	for range 3 {
+		request("192.0.2.1:1234")
+	}
+	request("192.0.2.2:1234")
+	if count := len(app.prerendered); count != 3 {
+		t.Errorf("%d prerendered sessions, expected 3 (2 for the first address and 1 for the second one)", count)
+	}
+
+	for id := range app.prerendered {
+		app.takePrerendered(id)
+	}
+	for i := range maxPrerenderedSessions + 1 {
+		request(fmt.Sprintf("10.0.%d.%d:80", i/256, i%256))
+	}
+	if count := len(app.prerendered); count != maxPrerenderedSessions {
+		t.Errorf("%d prerendered sessions, expected %d", count, maxPrerenderedSessions)
+	}
+	for id := range app.prerendered {
+		app.takePrerendered(id)
+	}
+
+	timeout := prerenderTimeout
+	prerenderTimeout = 10 * time.Millisecond
+	defer func() {
+		prerenderTimeout = timeout
+	}()
+
+	request("192.0.2.3:1234")
+	time.Sleep(100 * time.Millisecond)
+
+	app.prerenderMutex.Lock()
+	count := len(app.prerendered)
+	app.prerenderMutex.Unlock()
+	if count != 0 {
+		t.Error("the prerendered session is not removed after the timeout")
+	}
+}
+
+func TestContentSecurityPolicy(t *testing.T) {
+	app := createTestApp(t, AppParams{
+		ContentSecurityPolicy: DefaultContentSecurityPolicy,
+		FrameAncestors:        "'none'",
+		SecurityHeaders:       map[string]string{"X-Content-Type-Options": "nosniff"},
+	})
+
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	header := recorder.Header()
+	policy := header.Get("Content-Security-Policy")
+	_, nonce, _ := strings.Cut(policy, "'nonce-")
+	nonce, _, _ = strings.Cut(nonce, "'")
+	if nonce == "" || strings.Contains(policy, "{nonce}") {
+		t.Fatalf("invalid policy: %s", policy)
+	}
+	if !strings.HasSuffix(policy, "; frame-ancestors 'none'") {
+		t.Errorf("frame-ancestors is not added: %s", policy)
+	}
+	if header.Get("X-Frame-Options") != "DENY" || header.Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("invalid security headers: %v", header)
+	}
+	if header.Get("Strict-Transport-Security") != "" {
+		t.Error("Strict-Transport-Security is sent over HTTP")
+	}
+
+	page := recorder.Body.String()
+	if strings.Count(page, `nonce="`+nonce+`"`) != 4 {
+		t.Errorf("the inline blocks have no nonce:\n%s", page)
+	}
+	if strings.Contains(page, " onkeydown=") {
+		t.Error("the start page contains an inline event handler")
+	}
+}
+
+type shutdownTestContent struct {
+	testContent
+	events *[]string
+}
+
+func (content shutdownTestContent) OnShutdown(session Session) {
+	*content.events = append(*content.events, "shutdown")
+}
+
+func (content shutdownTestContent) OnFinish(session Session) {
+	*content.events = append(*content.events, "finish")
+}
+
+func TestStartAppContext(t *testing.T) {
+	events := []string{}
+	app := createTestApp(t, AppParams{ShutdownTimeout: time.Second})
+	app.createContentFunc = func(Session) SessionContent {
+		return shutdownTestContent{events: &events}
+	}
+
+	params, _ := ParseDataText("start-session{session=3}")
+	session := app.createSession(params, nil, nil, nil, nil, "")
+	if session == nil || !session.setContent(app.createContentFunc(session)) {
+		t.Fatal("the session is not created")
+	}
+
+	if err := app.shutdown(); err != nil {
+		t.Errorf("shutdown error: %v", err)
+	}
+	if text := strings.Join(events, ","); text != "shutdown,finish" {
+		t.Errorf(`session events = "%s", expected "shutdown,finish"`, text)
+	}
+	if len(app.sessionList()) != 0 {
+		t.Error("the session is not removed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := make(chan error)
+	go func() {
+		result <- StartAppContext(ctx, "127.0.0.1:0", func(Session) SessionContent {
+			return testContent{}
+		}, AppParams{})
+	}()
+
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("StartAppContext error: %v", err)
+		}
+
+	case <-time.After(3 * time.Second):
+		t.Fatal("the application is not finished after the context is canceled")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	createTestLog(t, true)
+
+	app := createTestApp(t, AppParams{ShutdownTimeout: 200 * time.Millisecond})
+
+	// nobody reads the events of the session
+	events := make(chan DataObject)
+	params, _ := ParseDataText("start-session{session=4}")
+	if app.createSession(params, events, nil, nil, nil, "") == nil {
+		t.Fatal("the session is not created")
+	}
+
+	start := time.Now()
+	app.shutdown()
+	if duration := time.Since(start); duration > time.Second {
+		t.Errorf("the shutdown takes %v, expected about 200ms", duration)
+	}
+
+	// StartAppContext does not register the handler in http.DefaultServeMux, so it can be called again
+	for range 2 {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := StartAppContext(ctx, "127.0.0.1:0", func(Session) SessionContent {
+			return testContent{}
+		}, AppParams{}); err != nil {
+			t.Errorf("StartAppContext error: %v", err)
+		}
+	}
+}
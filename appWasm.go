@@ -42,8 +42,8 @@ func errorLog(text string) {
 func (app *wasmApp) handleMessage(this js.Value, args []js.Value) any {
 	if len(args) > 0 {
 		text := args[0].String()
-		if ProtocolInDebugLog {
-			DebugLog(text)
+		if protocolLogEnabled() {
+			protocolLog(text)
 		}
 		if obj, _ := ParseDataText(text); obj != nil {
 			switch command := obj.Tag(); command {
@@ -63,10 +63,15 @@ func (app *wasmApp) handleMessage(this js.Value, args []js.Value) any {
 func (app *wasmApp) removeSession(id int) {
 }
 
+func (app *wasmApp) clientSessions(clientID string) []Session {
+	return nil
+}
+
 func (app *wasmApp) createSession() Session {
 	obj, _ := ParseDataText(js.Global().Call("sessionInfo", "").String())
 	session := newSession(app, 0, "", obj)
 	session.setBridge(app.close, app.bridge)
+	session.setConnectionState(SessionConnected)
 	session.setContent(app.createContentFunc(session))
 	return session
 }
@@ -5,7 +5,7 @@ async function sendMessage(message) {
 		createEventSource();
 	}
 
-	const response = await fetch('/', {
+	const response = await fetch(window.location.pathname, {
 		method			: 'POST',
 		body			: message,
 		"Content-Type"	: "text/plain",
@@ -13,7 +13,7 @@ async function sendMessage(message) {
 
 	const text = await response.text();
 	if (text != "") {
-		window.eval(text)
+		runServerMessage(text);
 	}
 }
 
@@ -26,9 +26,9 @@ function createEventSource() {
 }
 
 function onEventMessage(event) {
-	let script = base64ToString(event.data);
-	if (script != "") {
-		window.eval(script)
+	let text = base64ToString(event.data);
+	if (text != "") {
+		runServerMessage(text);
 	}
 }
 
@@ -13,6 +13,116 @@ window.onblur = function() {
 	sendMessage( "session-pause{session=" + sessionID +"}" );
 }
 
+// Event handlers of the elements are set by the "data-on<event>" attributes (e.g. data-onclick="clickEvent(this, event)")
+// instead of inline handlers, so the page can be used with a strict Content-Security-Policy.
+// The handler is a call of a global function, the arguments can be "this", "event", numbers, booleans and quoted strings.
+const eventHandlers = new Map();
+
+function parseEventHandler(text) {
+	if (eventHandlers.has(text)) {
+		return eventHandlers.get(text);
+	}
+
+	let handler = null;
+	const match = /^\s*([A-Za-z_$][\w$]*)\s*\(([^]*)\)\s*;?\s*$/.exec(text);
+	if (match) {
+		handler = { name: match[1], args: [] };
+		const argPattern = /\s*(?:(this|event|true|false|null)|(-?\d+(?:\.\d+)?)|'((?:[^'\\]|\\.)*)'|"((?:[^"\\]|\\.)*)")\s*(?:,|$)/y;
+		const argsText = match[2];
+		while (argPattern.lastIndex < argsText.length) {
+			const arg = argPattern.exec(argsText);
+			if (!arg) {
+				handler = null;
+				break;
+			}
+
+			if (arg[1]) {
+				switch (arg[1]) {
+				case "this":
+				case "event":
+					handler.args.push({ ref: arg[1] });
+					break;
+
+				default:
+					handler.args.push({ value: JSON.parse(arg[1]) });
+				}
+			} else if (arg[2]) {
+				handler.args.push({ value: Number(arg[2]) });
+			} else {
+				const str = arg[3] !== undefined ? arg[3] : arg[4];
+				handler.args.push({ value: str.replace(/\\(.)/g, "$1") });
+			}
+		}
+	}
+
+	if (!handler) {
+		console.error("Invalid event handler: " + text);
+	}
+	eventHandlers.set(text, handler);
+	return handler;
+}
+
+function callEventHandler(element, type, event) {
+	const text = element.getAttribute("data-on" + type);
+	if (!text) {
+		return;
+	}
+
+	const handler = parseEventHandler(text);
+	if (handler) {
+		const fn = window[handler.name];
+		if (typeof fn == "function") {
+			fn.apply(element, handler.args.map(arg => arg.ref == "this" ? element : arg.ref == "event" ? event : arg.value));
+		} else {
+			console.error("Unknown event handler: " + handler.name);
+		}
+	}
+}
+
+// the events which do not bubble: the handler of the target element only is called
+const targetEvents = [
+	"focus", "blur", "mouseenter", "mouseleave", "load", "error", "scroll", "toggle",
+	"abort", "canplay", "canplaythrough", "complete", "durationchange", "emptied", "ended",
+	"loadeddata", "loadedmetadata", "loadstart", "pause", "play", "playing", "progress", "ratechange",
+	"seeked", "seeking", "stalled", "suspend", "timeupdate", "volumechange", "waiting",
+];
+
+// the events which bubble: the handlers of the target element and its ancestors are called
+// until one of them stops the propagation
+const bubbleEvents = [
+	"click", "dblclick", "contextmenu", "keydown", "keyup", "input", "change",
+	"mousedown", "mouseup", "mousemove", "mouseout", "mouseover",
+	"pointerdown", "pointerup", "pointermove", "pointercancel", "pointerout", "pointerover",
+	"touchstart", "touchend", "touchmove", "touchcancel",
+	"transitionrun", "transitionstart", "transitionend", "transitioncancel",
+	"animationstart", "animationend", "animationiteration", "animationcancel",
+	"dragstart", "dragend", "dragenter", "dragleave", "dragover", "drop",
+];
+
+for (const type of targetEvents) {
+	document.addEventListener(type, function(event) {
+		if (event.target instanceof Element) {
+			callEventHandler(event.target, type, event);
+		}
+	}, { capture: true, passive: false });
+}
+
+for (const type of bubbleEvents) {
+	document.addEventListener(type, function(event) {
+		let element = event.target instanceof Element ? event.target : event.target.parentElement;
+		const attribute = "data-on" + type;
+		while (element) {
+			if (element.hasAttribute(attribute)) {
+				callEventHandler(element, type, event);
+				if (event.cancelBubble) {
+					break;
+				}
+			}
+			element = element.parentElement;
+		}
+	}, { passive: false });
+}
+
 function reloadPage() {
 	location.reload();
 }
@@ -254,6 +364,8 @@ function activateTab(layoutId, tabNumber) {
 				const tab = document.getElementById(layoutId + '-' + number);
 				if (tab) {	
 					tab.className = element.getAttribute(styleProperty);
+					tab.setAttribute("aria-selected", visibility == "" ? "true" : "false");
+					tab.setAttribute("tabindex", visibility == "" ? "0" : "-1");
 					const page = document.getElementById(tab.getAttribute("data-view"));
 					if (page) {
 						page.style.visibility = visibility
@@ -286,7 +398,56 @@ function tabClickEvent(tab, layoutId, tabNumber, event) {
 function tabKeyClickEvent(layoutId, tabNumber, event) {
 	if (enterOrSpaceKeyClickEvent(event)) {
 		tabClickEvent(null, layoutId, tabNumber, event)
+		return
+	}
+
+	const tab = document.getElementById(layoutId + '-' + tabNumber);
+	if (!tab || !tab.parentElement) {
+		return
 	}
+
+	const tabs = Array.from(tab.parentElement.children).filter(element => element.getAttribute("role") == "tab");
+	const vertical = tab.parentElement.getAttribute("aria-orientation") == "vertical";
+	let index = tabs.indexOf(tab);
+
+	switch (getKey(event)) {
+		case "ArrowLeft":
+			if (vertical) return;
+			index--;
+			break;
+
+		case "ArrowRight":
+			if (vertical) return;
+			index++;
+			break;
+
+		case "ArrowUp":
+			if (!vertical) return;
+			index--;
+			break;
+
+		case "ArrowDown":
+			if (!vertical) return;
+			index++;
+			break;
+
+		case "Home":
+			index = 0;
+			break;
+
+		case "End":
+			index = tabs.length - 1;
+			break;
+
+		default:
+			return;
+	}
+
+	index = (index + tabs.length) % tabs.length;
+	const next = tabs[index];
+	const nextNumber = next.id.substring(layoutId.length + 1);
+	tabClickEvent(null, layoutId, nextNumber, event);
+	next.focus();
 }
 
 function tabCloseClickEvent(button, layoutId, tabNumber, event) {
@@ -657,6 +818,7 @@ function selectListItem(element, item) {
 			if (current.classList) {
 				current.classList.remove(focusStyle, blurStyle);
 			}
+			setAriaSelected(element, current, null);
 			message = "itemUnselected{session=" + sessionID + ",id=" + element.id + "}";
 		}
 	}
@@ -673,6 +835,7 @@ function selectListItem(element, item) {
 		}
 
 		element.setAttribute("data-current", item.id);		
+		setAriaSelected(element, null, item);
 		const number = getListItemNumber(item.id)
 		if (number != undefined) {
 			message = "itemSelected{session=" + sessionID + ",id=" + element.id + ",number=" + number + "}";
@@ -691,6 +854,205 @@ function selectListItem(element, item) {
 	scanElementsSize();
 }
 
+function setAriaSelected(element, oldItem, newItem) {
+	if (oldItem && oldItem !== newItem) {
+		oldItem.setAttribute("aria-selected", "false");
+	}
+	if (newItem) {
+		newItem.setAttribute("aria-selected", "true");
+		element.setAttribute("aria-activedescendant", newItem.id);
+	} else {
+		element.removeAttribute("aria-activedescendant");
+	}
+}
+
+function announce(text, assertive) {
+	let region = document.getElementById("ruiLiveRegion");
+	if (!region) {
+		region = document.createElement("div");
+		region.id = "ruiLiveRegion";
+		region.className = "ruiLiveRegion";
+		region.setAttribute("aria-atomic", "true");
+		document.body.appendChild(region);
+	}
+	region.setAttribute("aria-live", assertive ? "assertive" : "polite");
+	region.textContent = "";
+	// the delay lets screen readers notice the change even if the same text is announced again
+	setTimeout(() => { region.textContent = text; }, 50);
+}
+
+// updateElement applies the updates of the element collected by the server:
+// ["style", name, value], ["attr", name, value], and ["removeAttr", name]
+function updateElement(elementId, commands) {
+	const element = document.getElementById(elementId);
+	if (!element) {
+		return;
+	}
+
+	for (const command of commands) {
+		switch (command[0]) {
+		case "style":
+			element.style[command[1]] = command[2];
+			break;
+
+		case "attr":
+			element.setAttribute(command[1], command[2]);
+			break;
+
+		case "removeAttr":
+			if (element.hasAttribute(command[1])) {
+				element.removeAttribute(command[1]);
+			}
+			break;
+		}
+	}
+	scanElementsSize();
+}
+
+// drawCanvas executes the drawing commands of the canvas: ["call", func, args...], ["set", property, value],
+// ["var", name, func, args...], ["path", name, svgPath], ["varCall", name, func, args...],
+// and ["image", url, property, func, args...]. The {var: name} argument is the variable created by "var" or "path"
+function drawCanvas(elementId, commands) {
+	const ctx = getCanvasContext(elementId);
+	if (!ctx) {
+		return;
+	}
+
+	const vars = new Map();
+	const value = arg => arg && typeof arg == "object" && !Array.isArray(arg) ? vars.get(arg.var) : arg;
+
+	for (const command of commands) {
+		const args = command.slice(1).map(value);
+		switch (command[0]) {
+		case "call":
+			ctx[args[0]](...args.slice(1));
+			break;
+
+		case "set":
+			ctx[args[0]] = args[1];
+			break;
+
+		case "var":
+			vars.set(args[0], ctx[args[1]](...args.slice(2)));
+			break;
+
+		case "path":
+			vars.set(args[0], args.length > 1 ? new Path2D(args[1]) : new Path2D());
+			break;
+
+		case "varCall": {
+			const v = vars.get(args[0]);
+			if (v) {
+				v[args[1]](...args.slice(2));
+			}
+			break;
+		}
+
+		case "image": {
+			const img = images.get(args[0]);
+			if (img) {
+				const result = ctx[args[2]](img, ...args.slice(3));
+				if (args[1]) {
+					ctx[args[1]] = result;
+				}
+			}
+			break;
+		}
+		}
+	}
+}
+
+// ruiCommands is the table of the functions which can be called by the server.
+// The functions of the transport scripts (app_socket.js, app_post.js) are called through wrappers
+// because the table is created before they are defined
+const ruiCommands = {
+	activateTab, announce, appendAnimationCSS, appendStyles, appendToInnerHTML, appendToInputValue,
+	blur, canvasTextMetrics, drawCanvas, focus, focusNext, focusPrevious, getPropertyValue, hideTooltip,
+	inspectorHighlight, inspectorPick, loadDropFile, loadImage, loadSelectedFile,
+	localStorageClear, localStorageGet, localStorageGetAll, localStorageRemove, localStorageSet,
+	mediaPause, mediaPlay, mediaSetPlaybackRate, mediaSetSetCurrentTime, mediaSetVolume,
+	openURL, printView, releaseFocus, reloadPage, removeProperty, removeView, scanElementsSize,
+	scrollIntoViewIfNeeded, scrollTo, scrollToEnd, scrollToStart, selectDropDownListItem,
+	setAnimationCSS, setConnectionLostText, setCssVar, setInputValue, setMediaMuted, setStyles,
+	setTableCellCursorByID, setTableRowCursorByID, setTitle, setTitleColor, showShutdownBanner,
+	startDownload, startTimer, stopTimer, trapFocus,
+	updateCSSProperty, updateCSSStyle, updateElement, updateInnerHTML, updateProperty,
+	closeSocket: (...args) => closeSocket(...args),
+	sendNop: (...args) => sendNop(...args),
+};
+
+// runServerMessage executes the message of the server: the lines of JSON arrays of commands.
+// A command is the array of the function name (see ruiCommands) and the arguments,
+// or the script which is sent by Session.RunScript (AppParams.AllowScripts)
+function runServerMessage(text) {
+	for (const line of text.split("\n")) {
+		if (line == "") {
+			continue;
+		}
+		for (const command of JSON.parse(line)) {
+			if (typeof command == "string") {
+				try {
+					window.eval(command);
+				} catch (error) {
+					console.error(error);
+				}
+			} else if (Object.hasOwn(ruiCommands, command[0])) {
+				ruiCommands[command[0]](...command.slice(1));
+			} else {
+				console.error("Unknown command: " + command[0]);
+			}
+		}
+	}
+}
+
+function showShutdownBanner(text) {
+	let banner = document.getElementById("ruiShutdownBanner");
+	if (!banner) {
+		banner = document.createElement("div");
+		banner.id = "ruiShutdownBanner";
+		banner.className = "ruiShutdownBanner";
+		banner.setAttribute("role", "alert");
+		document.body.appendChild(banner);
+	}
+	banner.textContent = text;
+}
+
+let connectionLostText = "The connection is lost. Reconnecting...";
+
+function setConnectionLostText(text) {
+	connectionLostText = text;
+	const element = document.getElementById("ruiConnectionLostText");
+	if (element) {
+		element.textContent = text;
+	}
+}
+
+// showConnectionLost shows or hides the overlay of the lost connection. The overlay does not catch
+// the pointer events, the events of the page are sent after the reconnection
+function showConnectionLost(show) {
+	let overlay = document.getElementById("ruiConnectionLost");
+	if (!show) {
+		if (overlay) {
+			overlay.remove();
+		}
+		return;
+	}
+
+	if (!overlay) {
+		overlay = document.createElement("div");
+		overlay.id = "ruiConnectionLost";
+		overlay.className = "ruiConnectionLost";
+		overlay.setAttribute("role", "alert");
+
+		const text = document.createElement("div");
+		text.id = "ruiConnectionLostText";
+		text.className = "ruiConnectionLostText";
+		text.textContent = connectionLostText;
+		overlay.appendChild(text);
+		document.body.appendChild(overlay);
+	}
+}
+
 function isListItemDisabled(item) {
 	let inert = item.getAttribute("inert");
 	if (inert != null) {
@@ -843,7 +1205,7 @@ function listViewKeyDownEvent(element, event) {
 					break;
 
 				case "Home":
-					for (const i of list.childNodes) {
+					for (const i of element.childNodes[0].childNodes) {
 						if (!isListItemDisabled(i)) {
 							item = i;
 							break;
@@ -852,8 +1214,9 @@ function listViewKeyDownEvent(element, event) {
 					break;
 
 				case "End":
-					for (var i = element.childNodes.length-1; i >= 0; i--) {
-						const itm = element.childNodes[i]
+					const items = element.childNodes[0].childNodes;
+					for (var i = items.length-1; i >= 0; i--) {
+						const itm = items[i]
 						if (!isListItemDisabled(itm)) {
 							item = itm;
 							break;
@@ -1307,6 +1670,196 @@ function blurCurrent() {
 	}
 }
 
+const focusTraps = [];
+
+function isFocusableElement(element) {
+	if (element.tabIndex < 0 || element.disabled || element.closest("[inert]")) {
+		return false;
+	}
+	if (element.getClientRects().length == 0) {
+		return false;
+	}
+	return getComputedStyle(element).visibility != "hidden";
+}
+
+function focusableElements(container) {
+	const result = [];
+	for (const element of container.querySelectorAll("[tabindex], a[href], button, input, select, textarea, summary")) {
+		if (isFocusableElement(element)) {
+			result.push(element);
+		}
+	}
+	return result;
+}
+
+function focusScope() {
+	if (focusTraps.length > 0) {
+		const container = document.getElementById(focusTraps[focusTraps.length - 1].id);
+		if (container) {
+			return container;
+		}
+	}
+	return document.getElementById("ruiRootView") || document.body;
+}
+
+function moveFocus(step) {
+	const elements = focusableElements(focusScope());
+	if (elements.length == 0) {
+		return;
+	}
+
+	let index = elements.indexOf(document.activeElement);
+	if (index < 0) {
+		index = step > 0 ? 0 : elements.length - 1;
+	} else {
+		index = (index + step + elements.length) % elements.length;
+	}
+	elements[index].focus();
+}
+
+function focusNext() {
+	moveFocus(1);
+}
+
+function focusPrevious() {
+	moveFocus(-1);
+}
+
+function trapFocus(containerId) {
+	focusTraps.push({ id: containerId, restore: document.activeElement });
+	blurCurrent();
+
+	const container = document.getElementById(containerId);
+	if (container) {
+		const elements = focusableElements(container);
+		if (elements.length > 0) {
+			elements[0].focus();
+		} else {
+			container.setAttribute("tabindex", "-1");
+			container.focus();
+		}
+	}
+}
+
+function releaseFocus(containerId) {
+	const index = focusTraps.findIndex(trap => trap.id == containerId);
+	if (index < 0) {
+		return;
+	}
+
+	const trap = focusTraps[index];
+	focusTraps.splice(index, 1);
+	if (index == focusTraps.length && trap.restore && trap.restore !== document.body && document.contains(trap.restore)) {
+		trap.restore.focus();
+	}
+}
+
+document.addEventListener("keydown", function(event) {
+	if (event.key == "Tab" && focusTraps.length > 0 && !event.altKey && !event.ctrlKey && !event.metaKey) {
+		event.preventDefault();
+		moveFocus(event.shiftKey ? -1 : 1);
+	}
+}, true);
+
+function printView(elementId) {
+	const element = elementId ? document.getElementById(elementId) : null;
+	if (!element) {
+		window.print();
+		return;
+	}
+
+	const container = document.createElement("div");
+	container.id = "ruiPrintContainer";
+	const clone = element.cloneNode(true);
+	container.appendChild(clone);
+
+	// the content of canvases and the values of the editors are not copied by cloneNode
+	const sources = element.querySelectorAll("canvas, input, textarea, select");
+	const copies = clone.querySelectorAll("canvas, input, textarea, select");
+	for (let i = 0; i < sources.length && i < copies.length; i++) {
+		if (sources[i] instanceof HTMLCanvasElement) {
+			copies[i].getContext("2d").drawImage(sources[i], 0, 0);
+		} else if (sources[i].type == "checkbox" || sources[i].type == "radio") {
+			copies[i].checked = sources[i].checked;
+		} else {
+			copies[i].value = sources[i].value;
+		}
+	}
+
+	document.body.appendChild(container);
+	document.body.classList.add("ruiPrintView");
+
+	const finish = function() {
+		window.removeEventListener("afterprint", finish);
+		document.body.classList.remove("ruiPrintView");
+		container.remove();
+	};
+	window.addEventListener("afterprint", finish);
+	window.print();
+}
+
+function inspectorViewElement(element) {
+	while (element && element !== document.body) {
+		if (element.id && /^id\d+$/.test(element.id)) {
+			return element;
+		}
+		element = element.parentElement;
+	}
+	return null;
+}
+
+function inspectorHighlight(elementId) {
+	let highlight = document.getElementById("ruiInspectorHighlight");
+	const element = elementId ? document.getElementById(elementId) : null;
+	if (!element) {
+		if (highlight) {
+			highlight.style.display = "none";
+		}
+		return;
+	}
+
+	if (!highlight) {
+		highlight = document.createElement("div");
+		highlight.id = "ruiInspectorHighlight";
+		highlight.className = "ruiInspectorHighlight";
+		document.body.appendChild(highlight);
+	}
+
+	const rect = element.getBoundingClientRect();
+	highlight.style.left = rect.left + "px";
+	highlight.style.top = rect.top + "px";
+	highlight.style.width = rect.width + "px";
+	highlight.style.height = rect.height + "px";
+	highlight.style.display = "block";
+}
+
+function inspectorMouseMove(event) {
+	const element = inspectorViewElement(event.target);
+	inspectorHighlight(element ? element.id : null);
+}
+
+function inspectorClick(event) {
+	event.preventDefault();
+	event.stopPropagation();
+	inspectorPick(false);
+
+	const element = inspectorViewElement(event.target);
+	if (element) {
+		sendMessage("inspector-pick{session=" + sessionID + ",id=" + element.id + "}");
+	}
+}
+
+function inspectorPick(enable) {
+	document.removeEventListener("mousemove", inspectorMouseMove, true);
+	document.removeEventListener("click", inspectorClick, true);
+	if (enable) {
+		document.addEventListener("mousemove", inspectorMouseMove, true);
+		document.addEventListener("click", inspectorClick, true);
+	} else {
+		inspectorHighlight(null);
+	}
+}
+
 function playerEvent(element, tag) {
 	//event.stopPropagation();
 	sendMessage(tag + "{session=" + sessionID + ",id=" + element.id + "}");
@@ -1488,6 +2041,7 @@ function setTableCellCursorByID(tableID, row, column) {
 					oldCell.classList.remove(focusStyle);
 					oldCell.classList.remove(getTableSelectedItemStyle(table));
 				}
+				setAriaSelected(table, oldCell, null);
 				table.removeAttribute("data-current");
 			}
 		}
@@ -1503,8 +2057,9 @@ function setTableCellCursor(element, row, column) {
 
 	const focusStyle = getTableFocusedItemStyle(element);
 	const oldCellID = element.getAttribute("data-current");
+	let oldCell;
 	if (oldCellID) {
-		const oldCell = document.getElementById(oldCellID);
+		oldCell = document.getElementById(oldCellID);
 		if (oldCell && oldCell.classList) {
 			oldCell.classList.remove(focusStyle);
 			oldCell.classList.remove(getTableSelectedItemStyle(element));
@@ -1513,6 +2068,7 @@ function setTableCellCursor(element, row, column) {
 
 	cell.classList.add(focusStyle);
 	element.setAttribute("data-current", cellID);
+	setAriaSelected(element, oldCell, cell);
 	if (cell.scrollIntoViewIfNeeded) {
 		cell.scrollIntoViewIfNeeded()
 	} else {
@@ -1679,6 +2235,7 @@ function setTableRowCursorByID(tableID, row) {
 					oldRow.classList.remove(focusStyle);
 					oldRow.classList.remove(getTableSelectedItemStyle(table));
 				}
+				setAriaSelected(table, oldRow, null);
 				table.removeAttribute("data-current");
 			}
 		}
@@ -1694,8 +2251,9 @@ function setTableRowCursor(element, row) {
 
 	const focusStyle = getTableFocusedItemStyle(element);
 	const oldRowID = element.getAttribute("data-current");
+	let oldRow;
 	if (oldRowID) {
-		const oldRow = document.getElementById(oldRowID);
+		oldRow = document.getElementById(oldRowID);
 		if (oldRow && oldRow.classList) {
 			oldRow.classList.remove(focusStyle);
 			oldRow.classList.remove(getTableSelectedItemStyle(element));
@@ -1704,6 +2262,7 @@ function setTableRowCursor(element, row) {
 
 	tableRow.classList.add(focusStyle);
 	element.setAttribute("data-current", tableRowID);
+	setAriaSelected(element, oldRow, tableRow);
 	if (tableRow.scrollIntoViewIfNeeded) {
 		tableRow.scrollIntoViewIfNeeded()
 	} else {
@@ -1,17 +1,32 @@
 let socket;
+let reconnectTimer;
+let reconnectAttempt = 0;
+
+// the messages which are sent while the connection is lost, they are sent after the reconnection
+const pendingMessages = [];
+const maxPendingMessages = 1000;
 
 function sendMessage(message) {
-	if (!socket) {
-		createSocket(function() {
-			sendReconnectMessage();
-			if (!windowFocus) {
-				windowFocus = true;
-				sendMessage( "session-resume{session=" + sessionID +"}" );
-			}
-			socket.send(message);
-		});
-	} else {
+	if (socket && socket.readyState == WebSocket.OPEN) {
 		socket.send(message);
+		return;
+	}
+
+	if (pendingMessages.length >= maxPendingMessages) {
+		pendingMessages.shift();
+	}
+	pendingMessages.push(message);
+
+	if (!socket && !reconnectTimer) {
+		const resume = !windowFocus;
+		windowFocus = true;
+		socketReconnect(resume);
+	}
+}
+
+function sendPendingMessages() {
+	while (pendingMessages.length > 0 && socket && socket.readyState == WebSocket.OPEN) {
+		socket.send(pendingMessages.shift());
 	}
 }
 
@@ -25,13 +40,16 @@ function createSocket(onopen) {
 	socketUrl += window.location.pathname + "ws";
 
 	socket = new WebSocket(socketUrl);
-	socket.onopen = onopen;
+	socket.onopen = function() {
+		reconnectAttempt = 0;
+		onopen();
+	};
 	socket.onclose = onSocketClose;
 	socket.onerror = onSocketError;
 	socket.onmessage = function(event) {
-		window.execScript ? window.execScript(event.data) : window.eval(event.data);
+		runServerMessage(event.data);
 	};
-} 
+}
 
 function closeSocket() {
 	if (socket) {
@@ -41,31 +59,59 @@ function closeSocket() {
 
 window.onload = createSocket(function() {
 	sendMessage( sessionInfo("start-session") );
+	sendPendingMessages();
 });
 
 window.onfocus = function() {
 	windowFocus = true;
 	if (!socket) {
-		createSocket(function() {
-			sendReconnectMessage();
-			sendMessage( "session-resume{session=" + sessionID +"}" );
-		});
+		socketReconnect(true);
 	} else {
 		sendMessage( "session-resume{session=" + sessionID +"}" );
 	}
 }
 
-function socketReconnect() {
+function socketReconnect(resume) {
+	if (reconnectTimer) {
+		clearTimeout(reconnectTimer);
+		reconnectTimer = null;
+	}
+
 	if (!socket) {
-		createSocket(sendReconnectMessage);
+		createSocket(function() {
+			sendReconnectMessage();
+			if (resume) {
+				sendMessage( "session-resume{session=" + sessionID +"}" );
+			}
+			sendPendingMessages();
+			showConnectionLost(false);
+		});
 	}
 }
 
+// scheduleReconnect starts the next attempt to restore the connection after the exponential delay (see reconnectPolicy)
+function scheduleReconnect() {
+	if (reconnectTimer) {
+		return;
+	}
+
+	const delay = Math.min(reconnectPolicy.delay * Math.pow(2, reconnectAttempt), reconnectPolicy.maxDelay);
+	reconnectAttempt++;
+	// the random part of the delay spreads the reconnections of the clients after a restart of the server
+	reconnectTimer = window.setTimeout(function() {
+		reconnectTimer = null;
+		socketReconnect(false);
+	}, delay * (0.8 + Math.random() * 0.4));
+}
+
 function onSocketClose(event) {
 	console.log("socket closed");
 	socket = null;
-	if (!event.wasClean && windowFocus) {
-		window.setTimeout(socketReconnect, 10000);
+	if (!event.wasClean) {
+		showConnectionLost(true);
+		if (windowFocus) {
+			scheduleReconnect();
+		}
 	}
 }
 
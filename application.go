@@ -2,8 +2,11 @@ package rui
 
 import (
 	_ "embed"
+	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //go:embed app_scripts.js
@@ -25,6 +28,7 @@ type Application interface {
 
 	removeSession(id int)
 	getCreateContentFunc() func(Session) SessionContent
+	clientSessions(clientID string) []Session
 }
 
 // AppParams defines parameters of the app
@@ -66,9 +70,180 @@ type AppParams struct {
 	//
 	//   GoogleFonts : "https://fonts.googleapis.com/css2?family=Open+Sans:ital,wght@0,300..800;1,300..800&family=Roboto:ital,wght@0,100..900;1,100..900&display=swap"
 	GoogleFonts string
+
+	// Prerender - if true then the root view of a new session is created when the start page is requested
+	// and its HTML and the theme styles are written into the start page. It makes the first paint faster
+	// and the page crawlable. When the socket connects, the session is continued with the information
+	// of the client (dark theme, screen size, etc.) and the root view is updated.
+	// Until then the session knows only the language and the user agent of the client.
+	Prerender bool
+
+	// ContentSecurityPolicy - the value of the "Content-Security-Policy" header of the start page.
+	// If it is empty then the header is not sent. The "{nonce}" placeholder is replaced by the nonce
+	// which is generated for each start page and is assigned to its inline script and style blocks.
+	// The event handlers of views are attached by the script, so inline handlers are not required.
+	// See DefaultContentSecurityPolicy
+	ContentSecurityPolicy string
+
+	// FrameAncestors - the sources which can embed the app in a frame, e.g. "'none'", "'self'", or "https://example.com".
+	// It is added to the Content-Security-Policy header as the "frame-ancestors" directive (if the policy
+	// does not contain it) and is duplicated by the "X-Frame-Options" header for "'none'" and "'self'"
+	FrameAncestors string
+
+	// StrictTransportSecurity - the value of the "Strict-Transport-Security" header, e.g. "max-age=31536000; includeSubDomains".
+	// The header is sent over HTTPS only
+	StrictTransportSecurity string
+
+	// SecurityHeaders - additional headers which are sent with all responses,
+	// e.g. {"X-Content-Type-Options": "nosniff", "Referrer-Policy": "same-origin"}
+	SecurityHeaders map[string]string
+
+	// Authenticate - the function which authenticates the HTTP requests of the start page, of the socket connection
+	// and of the session messages (in NoSocket mode) before a session is created or reconnected.
+	// The returned Principal is available as Session.User(). If the function returns an error then
+	// the request is rejected with the 401 (Unauthorized) status or, for the start page,
+	// is redirected if the error is AuthRedirect. A client reconnects to its session only
+	// if the name of the principal is not changed
+	Authenticate func(req *http.Request) (Principal, error)
+
+	// AllowedOrigins - the origins (e.g. "https://example.com") from which the socket can be opened
+	// and the session messages can be posted. "*" allows all origins.
+	// If the list is empty then only the origin of the app server is allowed.
+	// Requests without the "Origin" header (not from a browser) are always allowed
+	AllowedOrigins []string
+
+	// MaxMessageSize - the maximal size of a message of the client in bytes. The connection is closed
+	// if a message is larger. If the value is 0 then DefaultMaxMessageSize is used.
+	// If the value is negative then the size is not limited.
+	// Note that the files selected by FilePicker are sent by messages
+	MaxMessageSize int64
+
+	// EventRateLimit - the maximal number of messages per second from the client of a session.
+	// If the value is less than or equal to 0 then the rate is not limited
+	EventRateLimit int
+
+	// EventRateBurst - the number of messages which can be received at once before EventRateLimit
+	// is applied. If the value is less than EventRateLimit then EventRateLimit is used
+	EventRateBurst int
+
+	// RateLimitPolicy - the handling of the messages which exceed EventRateLimit:
+	// DropExcessEvents (default), DisconnectOnExcess, or CloseSessionOnExcess
+	RateLimitPolicy int
+
+	// MaxSessionsPerAddress - the maximal number of concurrent sessions of one remote address.
+	// If the value is less than or equal to 0 then the number is not limited.
+	// Behind a reverse proxy all clients have the address of the proxy
+	MaxSessionsPerAddress int
+
+	// ShutdownTimeout - the time which is given to the sessions to finish and to the HTTP server
+	// to shut down when the application is finished (see StartAppContext and FinishApp).
+	// If the value is less than or equal to 0 then 5 seconds are used
+	ShutdownTimeout time.Duration
+
+	// ShutdownMessage - the text of the banner which is shown to the clients when the server is going
+	// to shut down. The text is translated by Session.GetString. If it is empty then DefaultShutdownMessage is used
+	ShutdownMessage string
+
+	// ReconnectDelay - the delay before the first attempt of the client to restore the lost connection.
+	// The delay is doubled after each failed attempt up to MaxReconnectDelay.
+	// If the value is less than or equal to 0 then DefaultReconnectDelay is used
+	ReconnectDelay time.Duration
+
+	// MaxReconnectDelay - the maximal delay between the attempts of the client to restore the lost connection.
+	// If the value is less than ReconnectDelay then DefaultMaxReconnectDelay (or ReconnectDelay if it is greater) is used
+	MaxReconnectDelay time.Duration
+
+	// ConnectionLostMessage - the text of the overlay which is shown to the client while the connection is lost.
+	// The text is translated by Session.GetString. If it is empty then DefaultConnectionLostMessage is used.
+	// The colors of the overlay are set by the "ruiConnectionLostBackground" and "ruiConnectionLostTextColor"
+	// theme colors, the overlay has the "ruiConnectionLost" CSS class
+	ConnectionLostMessage string
+
+	// Metrics - if true then the metrics of the application (active sessions, connections, events, message sizes,
+	// answer latency, file transfers, and goroutines) are collected and served at the "/metrics" path
+	// in the Prometheus text format. The endpoint is not authenticated, restrict access to it by a middleware
+	// (see Server.Use) or by a reverse proxy
+	Metrics bool
+
+	// CompressionLevel - the level of the permessage-deflate compression of the socket messages:
+	// from 1 (best speed) to 9 (best compression). If the value is 0 then the default level (1) is used.
+	// If the value is negative then the messages are not compressed
+	CompressionLevel int
+
+	// AllowScripts - if true then Session.RunScript executes scripts on the client side.
+	// The scripts are executed by eval, so 'unsafe-eval' must be added to the "script-src" directive
+	// of ContentSecurityPolicy. The library itself sends the commands only and does not require eval
+	AllowScripts bool
+
+	// ClientIDCookie - the name of the persistent cookie which identifies the browser of the client
+	// (see Session.ClientID). If it is empty then the client identifier is not used, so Session.BroadcastToClient,
+	// Session.Logout and ClientSessionStartListener/ClientSessionFinishListener do not reach the other tabs of the browser
+	ClientIDCookie string
+
+	// HealthCheck - if true then the "/healthz" path responds with the 200 (OK) status,
+	// or with the 503 (Service Unavailable) status while the application is shutting down
+	HealthCheck bool
 }
 
-func getStartPage(buffer *strings.Builder, sessionID int, params AppParams) {
+// Constants for AppParams.RateLimitPolicy
+const (
+	// DropExcessEvents - the messages exceeding AppParams.EventRateLimit are ignored
+	DropExcessEvents = 0
+
+	// DisconnectOnExcess - the connection is closed when AppParams.EventRateLimit is exceeded.
+	// The session is kept and the client can reconnect to it. In the NoSocket mode the excess messages are rejected
+	DisconnectOnExcess = 1
+
+	// CloseSessionOnExcess - the session is finished when AppParams.EventRateLimit is exceeded
+	CloseSessionOnExcess = 2
+)
+
+// DefaultShutdownMessage is the text of the banner which is shown to the clients when the server is going to shut down
+const DefaultShutdownMessage = "The server is going away. The page will be reloaded when the connection is restored."
+
+// DefaultConnectionLostMessage is the text of the overlay which is shown to the client while the connection is lost
+const DefaultConnectionLostMessage = "The connection is lost. Reconnecting..."
+
+// DefaultReconnectDelay is the delay before the first attempt to restore the lost connection if AppParams.ReconnectDelay is 0
+const DefaultReconnectDelay = time.Second
+
+// DefaultMaxReconnectDelay is the maximal delay between the attempts to restore the lost connection
+// if AppParams.MaxReconnectDelay is 0
+const DefaultMaxReconnectDelay = 30 * time.Second
+
+// DefaultMaxMessageSize is the maximal size of a message of the client if AppParams.MaxMessageSize is 0
+const DefaultMaxMessageSize = 16 << 20
+
+// DefaultContentSecurityPolicy is the Content-Security-Policy which can be used as AppParams.ContentSecurityPolicy.
+// Scripts are allowed from the app server and by the nonce of the page only. Inline styles are allowed
+// because views are rendered with the "style" attribute. Add 'unsafe-eval' to "script-src"
+// if AppParams.AllowScripts is used.
+const DefaultContentSecurityPolicy = "default-src 'self'; " +
+	"script-src 'self' 'nonce-{nonce}'; " +
+	"style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data: blob:; " +
+	"media-src 'self' data: blob:; " +
+	"font-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"object-src 'none'; " +
+	"base-uri 'self'"
+
+// reconnectDelays returns the delay before the first attempt to restore the lost connection
+// and the maximal delay between the attempts
+func (params AppParams) reconnectDelays() (time.Duration, time.Duration) {
+	delay := params.ReconnectDelay
+	if delay <= 0 {
+		delay = DefaultReconnectDelay
+	}
+
+	maxDelay := params.MaxReconnectDelay
+	if maxDelay < delay {
+		maxDelay = max(DefaultMaxReconnectDelay, delay)
+	}
+	return delay, maxDelay
+}
+
+func getStartPage(buffer *strings.Builder, sessionID int, params AppParams, prerendered Session, nonce string) {
 	buffer.WriteString(`<head>
 		<meta charset="utf-8">
 		<title>`)
@@ -101,20 +276,38 @@ func getStartPage(buffer *strings.Builder, sessionID int, params AppParams) {
 		buffer.WriteString(`" rel="stylesheet">`)
 	}
 
+	nonceAttr := ""
+	if nonce != "" {
+		nonceAttr = ` nonce="` + nonce + `"`
+	}
+
 	buffer.WriteString(`
-		<style>`)
+		<style` + nonceAttr + `>`)
 	buffer.WriteString(appStyles)
+	if prerendered != nil {
+		buffer.WriteString(unescapeScriptCSS(prerendered.getCurrentTheme().cssText(prerendered)))
+	}
 	buffer.WriteString(`</style>
-		<style id="ruiAnimations"></style>
-		<script>
+		<style id="ruiAnimations"` + nonceAttr + `></style>
+		<script` + nonceAttr + `>
 const sessionID = `)
 	buffer.WriteString(strconv.Itoa(sessionID))
 	buffer.WriteString(`;
+const reconnectPolicy = `)
+	delay, maxDelay := params.reconnectDelays()
+	buffer.WriteString(fmt.Sprintf("{ delay: %d, maxDelay: %d }", delay.Milliseconds(), maxDelay.Milliseconds()))
+	buffer.WriteString(`;
 	</script>
-	<script src="/script.js"></script>
+	<script src="script.js"` + nonceAttr + `></script>
 	</head>
-	<body id="body" onkeydown="keyDownEvent(this, event)">
-		<div class="ruiRoot" id="ruiRootView"></div>
+	<body id="body" data-onkeydown="keyDownEvent(this, event)">
+		<div class="ruiRoot" id="ruiRootView">`)
+	if prerendered != nil {
+		if view := prerendered.RootView(); view != nil {
+			viewHTML(view, buffer, "")
+		}
+	}
+	buffer.WriteString(`</div>
 		<div class="ruiPopupLayer" id="ruiPopupLayer" style="visibility: hidden; isolation: isolate; z-index: 10001;"></div>
 		<div class="ruiTooltipLayer" id="ruiTooltipLayer" style="visibility: hidden; opacity: 0; z-index: 10002;">
 			<div id="ruiTooltipText" class="ruiTooltipText"></div>
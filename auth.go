@@ -0,0 +1,34 @@
+package rui
+
+// Principal describes the authenticated user of a session (see AppParams.Authenticate and Session.User)
+type Principal interface {
+	// Name returns the unique name of the user. It is used to check that the reconnecting client
+	// is the same user
+	Name() string
+}
+
+// AuthRedirect is the error which can be returned by AppParams.Authenticate to redirect
+// the rejected request of the start page to the URL (e.g. to the login page)
+type AuthRedirect struct {
+	// URL is the address to which the request is redirected
+	URL string
+}
+
+func (redirect AuthRedirect) Error() string {
+	return "authentication required, redirect to " + redirect.URL
+}
+
+func samePrincipal(user1, user2 Principal) bool {
+	if user1 == nil || user2 == nil {
+		return user1 == nil && user2 == nil
+	}
+	return user1.Name() == user2.Name()
+}
+
+func (session *sessionData) User() Principal {
+	return session.user
+}
+
+func (session *sessionData) setUser(user Principal) {
+	session.user = user
+}
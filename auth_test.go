@@ -0,0 +1,72 @@
+package rui
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testPrincipal string
+
+func (user testPrincipal) Name() string {
+	return string(user)
+}
+
+func TestAuthenticate(t *testing.T) {
+	app := createTestApp(t, AppParams{
+		Authenticate: func(req *http.Request) (Principal, error) {
+			if cookie, err := req.Cookie("user"); err == nil {
+				return testPrincipal(cookie.Value), nil
+			}
+			if req.URL.Query().Has("login") {
+				return nil, AuthRedirect{URL: "/login"}
+			}
+			return nil, errors.New("no user")
+		},
+	})
+
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, expected 401", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/?login", nil))
+	if recorder.Code != http.StatusFound || recorder.Header().Get("Location") != "/login" {
+		t.Errorf("status = %d, location = %s, expected redirect to /login", recorder.Code, recorder.Header().Get("Location"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "user", Value: "alice"})
+	recorder = httptest.NewRecorder()
+	app.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, expected 200", recorder.Code)
+	}
+
+	params, _ := ParseDataText("start-session{session=7}")
+	session := app.createSession(params, nil, nil, nil, testPrincipal("alice"), "")
+	if session == nil || session.User() == nil || session.User().Name() != "alice" {
+		t.Fatal("the principal is not assigned to the session")
+	}
+	if samePrincipal(session.User(), testPrincipal("bob")) || samePrincipal(session.User(), nil) {
+		t.Error("samePrincipal returns true for different users")
+	}
+
+	bridge := createHttpBridge(httptest.NewRequest(http.MethodPost, "/", nil))
+	params, _ = ParseDataText("start-session{session=8}")
+	if app.createSession(params, nil, bridge, bridge.response, testPrincipal("alice"), "") == nil {
+		t.Fatal("the session is not created")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("click-event{session=8}"))
+	req.AddCookie(&http.Cookie{Name: "user", Value: "bob"})
+	recorder = httptest.NewRecorder()
+	app.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK || recorder.Body.String() != `[["reloadPage"]]` {
+		t.Errorf("status = %d, body = %s, expected the reload of the page for another user", recorder.Code, recorder.Body.String())
+	}
+}
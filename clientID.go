@@ -0,0 +1,96 @@
+//go:build !wasm
+
+package rui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// clientIDCookieAge is the lifetime of the cookie of the client identifier (see AppParams.ClientIDCookie)
+const clientIDCookieAge = 400 * 24 * time.Hour
+
+// maxClientIDLength is the maximal length of the client identifier which is accepted from the cookie
+const maxClientIDLength = 64
+
+// requestClientID returns the client identifier of the request or "" if the cookie is not set
+// or AppParams.ClientIDCookie is empty
+func (app *application) requestClientID(req *http.Request) string {
+	if app.params.ClientIDCookie == "" {
+		return ""
+	}
+
+	if cookie, err := req.Cookie(app.params.ClientIDCookie); err == nil && len(cookie.Value) <= maxClientIDLength {
+		return cookie.Value
+	}
+	return ""
+}
+
+// startPageClientID returns the client identifier of the start page request. If the client has no identifier
+// then the new one is generated and the cookie is set. The function must be called before the response status is written
+func (app *application) startPageClientID(w http.ResponseWriter, req *http.Request) string {
+	if app.params.ClientIDCookie == "" {
+		return ""
+	}
+
+	clientID := app.requestClientID(req)
+	if clientID == "" {
+		data := make([]byte, 16)
+		if _, err := rand.Read(data); err != nil {
+			ErrorLog(err.Error())
+			return ""
+		}
+		clientID = hex.EncodeToString(data)
+	}
+
+	// the cookie is sent again to prolong its lifetime
+	http.SetCookie(w, &http.Cookie{
+		Name:     app.params.ClientIDCookie,
+		Value:    clientID,
+		Path:     "/",
+		MaxAge:   int(clientIDCookieAge.Seconds()),
+		Secure:   req.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return clientID
+}
+
+// clientSessions returns the sessions of the client
+func (app *application) clientSessions(clientID string) []Session {
+	result := []Session{}
+	if clientID != "" {
+		for _, info := range app.sessionList() {
+			if info.session.ClientID() == clientID {
+				result = append(result, info.session)
+			}
+		}
+	}
+	return result
+}
+
+// notifyClientSessions calls the ClientSessionStartListener (if started is true) or ClientSessionFinishListener
+// of the other sessions of the client of the session
+func (app *application) notifyClientSessions(session Session, started bool) {
+	clientID := session.ClientID()
+	if clientID == "" || app.shuttingDown.Load() {
+		return
+	}
+
+	sessionID := session.ID()
+	for _, other := range app.clientSessions(clientID) {
+		if other.ID() != sessionID {
+			if started {
+				other.queueCall(func(other Session) {
+					other.onClientSessionStart(sessionID)
+				})
+			} else {
+				other.queueCall(func(other Session) {
+					other.onClientSessionFinish(sessionID)
+				})
+			}
+		}
+	}
+}
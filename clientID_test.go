@@ -0,0 +1,98 @@
+package rui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClientID(t *testing.T) {
+	app := createTestApp(t, AppParams{ClientIDCookie: "rui-client"})
+
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "rui-client" || len(cookies[0].Value) != 32 || !cookies[0].HttpOnly {
+		t.Fatalf("invalid client cookie: %v", cookies)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	recorder = httptest.NewRecorder()
+	app.ServeHTTP(recorder, req)
+	if cookies := recorder.Result().Cookies(); len(cookies) != 1 || cookies[0].Value != req.Cookies()[0].Value {
+		t.Errorf("the client identifier is changed: %v", cookies)
+	}
+
+	events := []string{}
+	createSession := func(sessionID int, clientID string) (Session, chan DataObject) {
+		queue := make(chan DataObject, 16)
+		params, _ := ParseDataText(fmt.Sprintf("start-session{session=%d}", sessionID))
+		bridge := createHttpBridge(httptest.NewRequest(http.MethodPost, "/", nil))
+		session := app.createSession(params, queue, bridge, bridge.response, testPrincipal("alice"), clientID)
+		if session == nil {
+			t.Fatal("the session is not created")
+		}
+		session.(*sessionData).content = clientTestContent{events: &events}
+		return session, queue
+	}
+	handleEvents := func(session Session, queue chan DataObject) {
+		for len(queue) > 0 {
+			data := <-queue
+			session.handleEvent(data.Tag(), data)
+		}
+	}
+
+	session1, queue1 := createSession(1, "client1")
+	session2, queue2 := createSession(2, "client1")
+	session3, queue3 := createSession(3, "client2")
+	handleEvents(session1, queue1)
+	handleEvents(session3, queue3)
+
+	session2.BroadcastToClient(func(session Session) {
+		events = append(events, fmt.Sprintf("%d: broadcast", session.ID()))
+	})
+	handleEvents(session1, queue1)
+	handleEvents(session3, queue3)
+
+	session1.Logout()
+	handleEvents(session2, queue2)
+	if session1.User() != nil || session2.User() != nil || session3.User() == nil {
+		t.Error("Logout must remove the user from the sessions of the client only")
+	}
+
+	// the output of the queued calls is not a response, the HTTP client receives it with the next response
+	bridge2 := session2.(*sessionData).bridge.(*httpBridge)
+	if len(bridge2.response) != 0 {
+		t.Errorf("the response is sent without a request: %s", <-bridge2.response)
+	}
+	session2.sendResponse()
+	if text := <-bridge2.response; !strings.Contains(text, "reloadPage") {
+		t.Errorf("the output of the queued call is lost: %s", text)
+	}
+
+	app.removeSession(2)
+	handleEvents(session1, queue1)
+	handleEvents(session3, queue3)
+
+	expected := []string{"1: start 2", "2: broadcast", "1: broadcast", "1: finish 2"}
+	if !slices.Equal(events, expected) {
+		t.Errorf("events: %v, expected: %v", events, expected)
+	}
+}
+
+type clientTestContent struct {
+	testContent
+	events *[]string
+}
+
+func (content clientTestContent) OnClientSessionStart(session Session, otherSessionID int) {
+	*content.events = append(*content.events, fmt.Sprintf("%d: start %d", session.ID(), otherSessionID))
+}
+
+func (content clientTestContent) OnClientSessionFinish(session Session, otherSessionID int) {
+	*content.events = append(*content.events, fmt.Sprintf("%d: finish %d", session.ID(), otherSessionID))
+}
@@ -0,0 +1,351 @@
+// Command ruifmt formats and checks the resource files (.rui) of the rui library.
+//
+// Usage:
+//
+//	ruifmt [flags] [path ...]
+//
+// Without flags the formatted text of each file is written to the standard output.
+// A directory argument is processed recursively.
+//
+// Flags:
+//
+//	-w      write the result to the source file instead of the standard output
+//	-l      list files whose formatting differs from ruifmt's
+//	-lint   check files instead of formatting them
+//	-views  comma-separated list of custom view tags (registered by RegisterViewCreator in the application)
+//
+// The checks performed by -lint:
+//   - syntax errors;
+//   - references to undefined theme constants, colors and images (@name);
+//   - unknown view tags and property names of views;
+//   - strings missing in some languages.
+//
+// Themes and strings are collected from all checked files, so the whole resource directory should be
+// passed to find undefined references and missing strings.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/anoshenko/rui"
+)
+
+var (
+	writeFlag = flag.Bool("w", false, "write result to the source file instead of stdout")
+	listFlag  = flag.Bool("l", false, "list files whose formatting differs from ruifmt's")
+	lintFlag  = flag.Bool("lint", false, "check files instead of formatting them")
+	viewsFlag = flag.String("views", "", "comma-separated list of custom view tags")
+)
+
+type resourceFile struct {
+	path string
+	text string
+	data rui.DataObject
+}
+
+type problem struct {
+	position rui.DataPosition
+	message  string
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: ruifmt [flags] [path ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	rui.SetDebugLog(nil)
+	rui.SetErrorLog(nil)
+
+	files, err := collectFiles(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if len(files) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var ok bool
+	if *lintFlag {
+		ok = lint(files)
+	} else {
+		ok = format(files)
+	}
+
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func collectFiles(args []string) ([]string, error) {
+	files := []string{}
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		err = filepath.WalkDir(arg, func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() && strings.ToLower(filepath.Ext(path)) == ".rui" {
+				files = append(files, path)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func readFile(path string) (*resourceFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	text := string(data)
+	obj, err := rui.ParseDataFile(path, text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resourceFile{path: path, text: text, data: obj}, nil
+}
+
+func format(files []string) bool {
+	ok := true
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			ok = false
+			continue
+		}
+
+		text := string(data)
+		result, err := rui.FormatDataText(path, text)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			ok = false
+			continue
+		}
+
+		changed := result != text
+
+		if *listFlag {
+			if changed {
+				fmt.Println(path)
+			}
+		}
+
+		if *writeFlag {
+			if changed {
+				if err := os.WriteFile(path, []byte(result), 0644); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					ok = false
+				}
+			}
+		} else if !*listFlag {
+			os.Stdout.WriteString(result)
+		}
+	}
+	return ok
+}
+
+func lint(paths []string) bool {
+	for _, tag := range strings.Split(*viewsFlag, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			rui.RegisterViewCreator(tag, func(session rui.Session) rui.View {
+				return rui.NewView(session, nil)
+			})
+		}
+	}
+
+	problems := map[string][]problem{}
+	report := func(path string, position rui.DataPosition, message string) {
+		problems[path] = append(problems[path], problem{position: position, message: message})
+	}
+
+	files := []*resourceFile{}
+	for _, path := range paths {
+		file, err := readFile(path)
+		if err == nil {
+			files = append(files, file)
+		} else if dataErr, ok := err.(*rui.DataError); ok {
+			report(path, dataErr.Position, dataErr.Message)
+		} else {
+			report(path, rui.DataPosition{File: path}, err.Error())
+		}
+	}
+
+	defined := map[string]bool{}
+	addThemeNames := func(theme rui.Theme) {
+		for _, list := range [][]string{theme.ConstantTags(), theme.ColorTags(), theme.ImageConstantTags()} {
+			for _, tag := range list {
+				defined[tag] = true
+			}
+		}
+	}
+
+	addThemeNames(rui.DefaultTheme())
+	stringTable := map[string]map[string]rui.DataPosition{}
+
+	for _, file := range files {
+		switch tag := file.data.Tag(); {
+		case tag == "theme":
+			rui.SetErrorLog(func(message string) {
+				report(file.path, rui.DataPositionOf(file.data), message)
+			})
+			theme, ok := rui.CreateThemeFromFile(file.path, file.text)
+			rui.SetErrorLog(nil)
+
+			if ok {
+				addThemeNames(theme)
+			} else {
+				report(file.path, rui.DataPositionOf(file.data), "the theme is not loaded")
+			}
+
+		case tag == "strings":
+			for node := range file.data.Properties() {
+				if node.Type() == rui.ObjectNode {
+					addStrings(stringTable, node.Tag(), node.Object())
+				}
+			}
+
+		case strings.HasPrefix(tag, "strings:"):
+			addStrings(stringTable, tag[8:], file.data)
+		}
+	}
+
+	for _, file := range files {
+		checkReferences(file.data, defined, func(position rui.DataPosition, name string) {
+			report(file.path, position, fmt.Sprintf(`undefined constant "@%s"`, name))
+		})
+
+		switch tag := file.data.Tag(); {
+		case tag == "theme", tag == "popup", tag == "strings", strings.HasPrefix(tag, "strings:"):
+
+		default:
+			for _, err := range rui.ValidateViewText(file.path, file.text) {
+				report(file.path, err.Position, fmt.Sprintf(`"%s": %s`, err.Tag, err.Message))
+			}
+		}
+	}
+
+	checkStrings(stringTable, func(position rui.DataPosition, message string) {
+		report(position.File, position, message)
+	})
+
+	count := 0
+	for _, path := range slices.Sorted(maps.Keys(problems)) {
+		list := problems[path]
+		slices.SortStableFunc(list, func(a, b problem) int {
+			if a.position.Line != b.position.Line {
+				return a.position.Line - b.position.Line
+			}
+			return a.position.Column - b.position.Column
+		})
+
+		for _, p := range list {
+			position := p.position
+			position.File = path
+			fmt.Printf("%s: %s\n", position.String(), p.message)
+			count++
+		}
+	}
+
+	return count == 0
+}
+
+func addStrings(table map[string]map[string]rui.DataPosition, lang string, obj rui.DataObject) {
+	if lang == "" {
+		return
+	}
+
+	for node := range obj.Properties() {
+		if node.Type() == rui.TextNode {
+			langs, ok := table[node.Tag()]
+			if !ok {
+				langs = map[string]rui.DataPosition{}
+				table[node.Tag()] = langs
+			}
+			langs[lang] = rui.DataPositionOf(node)
+		}
+	}
+}
+
+func checkStrings(table map[string]map[string]rui.DataPosition, report func(rui.DataPosition, string)) {
+	languages := map[string]bool{}
+	for _, langs := range table {
+		for lang := range langs {
+			languages[lang] = true
+		}
+	}
+
+	allLanguages := slices.Sorted(maps.Keys(languages))
+	for tag, langs := range table {
+		missing := []string{}
+		for _, lang := range allLanguages {
+			if _, ok := langs[lang]; !ok {
+				missing = append(missing, lang)
+			}
+		}
+
+		if len(missing) > 0 {
+			position := langs[slices.Sorted(maps.Keys(langs))[0]]
+			report(position, fmt.Sprintf(`string "%s" is missing in languages: %s`, tag, strings.Join(missing, ", ")))
+		}
+	}
+}
+
+func checkReferences(obj rui.DataObject, defined map[string]bool, report func(rui.DataPosition, string)) {
+	var checkText func(text string, position rui.DataPosition)
+	checkText = func(text string, position rui.DataPosition) {
+		for _, token := range strings.FieldsFunc(text, func(r rune) bool {
+			return strings.ContainsRune(", :;|/\t\n()", r)
+		}) {
+			if len(token) > 1 && token[0] == '@' {
+				name := token[1:]
+				if !defined[name] {
+					report(position, name)
+				}
+			}
+		}
+	}
+
+	for node := range obj.Properties() {
+		switch node.Type() {
+		case rui.TextNode:
+			checkText(node.Text(), rui.DataPositionOf(node))
+
+		case rui.ObjectNode:
+			checkReferences(node.Object(), defined, report)
+
+		case rui.ArrayNode:
+			for value := range node.ArrayElements() {
+				if value.IsObject() {
+					checkReferences(value.Object(), defined, report)
+				} else {
+					checkText(value.Value(), rui.DataPositionOf(node))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package rui
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// colorFunctions contains the names of the functions which can be used in theme colors and constants:
+//   - lighten(color, amount) - increases the lightness of the color by the amount (0..1 or 0%..100%);
+//   - darken(color, amount) - decreases the lightness of the color by the amount;
+//   - alpha(color, opacity) - replaces the opacity of the color (0..1 or 0%..100%);
+//   - mix(color1, color2[, weight]) - mixes two colors, the weight (50% by default) is the part of the first color.
+//
+// The color argument is a color constant (@name), a color value or a nested function,
+// e.g. "darken(alpha(@ruiHighlightColor, 50%), 10%)".
+var colorFunctions = []string{"lighten", "darken", "alpha", "mix"}
+
+func isColorFunction(text string) bool {
+	text = strings.TrimSpace(text)
+	if index := strings.IndexRune(text, '('); index > 0 && strings.HasSuffix(text, ")") {
+		name := strings.ToLower(strings.TrimSpace(text[:index]))
+		for _, fn := range colorFunctions {
+			if fn == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitFunctionArgs splits the arguments of the function by commas ignoring commas in nested brackets
+func splitFunctionArgs(text string) []string {
+	args := []string{}
+	depth := 0
+	start := 0
+	for i, ch := range text {
+		switch ch {
+		case '(':
+			depth++
+
+		case ')':
+			depth--
+
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(text[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(args, strings.TrimSpace(text[start:]))
+}
+
+func (session *sessionData) colorFunction(text string, darkMode bool, tags []string) (Color, bool) {
+	text = strings.TrimSpace(text)
+	index := strings.IndexRune(text, '(')
+	name := strings.ToLower(strings.TrimSpace(text[:index]))
+	args := splitFunctionArgs(text[index+1 : len(text)-1])
+
+	colorArg := func(arg string) (Color, bool) {
+		switch {
+		case strings.HasPrefix(arg, "@"):
+			return session.getColorNext(arg[1:], darkMode, tags)
+
+		case isColorFunction(arg):
+			return session.colorFunction(arg, darkMode, tags)
+		}
+
+		color, err := stringToColor(arg)
+		if err != nil {
+			ErrorLogF(`invalid color "%s" in "%s"`, arg, text)
+			return 0, false
+		}
+		return color, true
+	}
+
+	amountArg := func(arg string) (float64, bool) {
+		percent := strings.HasSuffix(arg, "%")
+		arg = strings.TrimSuffix(arg, "%")
+		amount, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		if err != nil {
+			ErrorLogF(`invalid amount "%s" in "%s"`, arg, text)
+			return 0, false
+		}
+		if percent {
+			amount /= 100
+		}
+		return math.Max(0, math.Min(1, amount)), true
+	}
+
+	switch name {
+	case "lighten", "darken", "alpha":
+		if len(args) != 2 {
+			break
+		}
+
+		color, ok := colorArg(args[0])
+		if !ok {
+			return 0, false
+		}
+
+		amount, ok := amountArg(args[1])
+		if !ok {
+			return 0, false
+		}
+
+		switch name {
+		case "lighten":
+			return color.changeLightness(amount), true
+
+		case "darken":
+			return color.changeLightness(-amount), true
+		}
+
+		_, r, g, b := color.ARGB()
+		return ARGB(uint8(math.Round(amount*255)), r, g, b), true
+
+	case "mix":
+		if len(args) != 2 && len(args) != 3 {
+			break
+		}
+
+		color1, ok := colorArg(args[0])
+		if !ok {
+			return 0, false
+		}
+
+		color2, ok := colorArg(args[1])
+		if !ok {
+			return 0, false
+		}
+
+		weight := 0.5
+		if len(args) == 3 {
+			if weight, ok = amountArg(args[2]); !ok {
+				return 0, false
+			}
+		}
+
+		a1, r1, g1, b1 := color1.ARGB()
+		a2, r2, g2, b2 := color2.ARGB()
+		mix := func(c1, c2 uint8) uint8 {
+			return uint8(math.Round(float64(c1)*weight + float64(c2)*(1-weight)))
+		}
+		return ARGB(mix(a1, a2), mix(r1, r2), mix(g1, g2), mix(b1, b2)), true
+	}
+
+	ErrorLogF(`invalid number of arguments in "%s"`, text)
+	return 0, false
+}
+
+// changeLightness adds delta (-1..1) to the HSL lightness of the color
+func (color Color) changeLightness(delta float64) Color {
+	a, r, g, b := color.ARGB()
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+
+	maxValue := math.Max(rf, math.Max(gf, bf))
+	minValue := math.Min(rf, math.Min(gf, bf))
+	l := (maxValue + minValue) / 2
+
+	h, s := 0.0, 0.0
+	if maxValue != minValue {
+		d := maxValue - minValue
+		if l > 0.5 {
+			s = d / (2 - maxValue - minValue)
+		} else {
+			s = d / (maxValue + minValue)
+		}
+
+		switch maxValue {
+		case rf:
+			h = (gf - bf) / d
+			if gf < bf {
+				h += 6
+			}
+
+		case gf:
+			h = (bf-rf)/d + 2
+
+		default:
+			h = (rf-gf)/d + 4
+		}
+		h /= 6
+	}
+
+	l = math.Max(0, math.Min(1, l+delta))
+
+	if s == 0 {
+		v := uint8(math.Round(l * 255))
+		return ARGB(a, v, v, v)
+	}
+
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+
+	hue := func(t float64) uint8 {
+		if t < 0 {
+			t += 1
+		} else if t > 1 {
+			t -= 1
+		}
+
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+
+		case t < 0.5:
+			v = q
+
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+
+		default:
+			v = p
+		}
+		return uint8(math.Round(v * 255))
+	}
+
+	return ARGB(a, hue(h+1.0/3), hue(h), hue(h-1.0/3))
+}
@@ -151,9 +151,9 @@ func (picker *colorPickerData) htmlProperties(self View, buffer *strings.Builder
 	buffer.WriteString(GetColorPickerValue(picker).rgbString())
 	buffer.WriteByte('"')
 
-	buffer.WriteString(` oninput="editViewInputEvent(this)"`)
+	buffer.WriteString(` data-oninput="editViewInputEvent(this)"`)
 	if picker.getRaw(ClickEvent) == nil {
-		buffer.WriteString(` onclick="stopEventPropagation(this, event)"`)
+		buffer.WriteString(` data-onclick="stopEventPropagation(this, event)"`)
 	}
 
 	dataListHtmlProperties(picker, buffer)
@@ -0,0 +1,149 @@
+//go:build !wasm
+
+package rui
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// eventRateLimiter is the token bucket which limits the number of messages of a session per second
+type eventRateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newEventRateLimiter returns the limiter of the session or nil if the rate is not limited
+func newEventRateLimiter(rate, burst int) *eventRateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < rate {
+		burst = rate
+	}
+	return &eventRateLimiter{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// allow returns true if the next message can be handled. The nil limiter allows all messages
+func (limiter *eventRateLimiter) allow() bool {
+	if limiter == nil {
+		return true
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := time.Now()
+	limiter.tokens += now.Sub(limiter.last).Seconds() * limiter.rate
+	limiter.last = now
+	if limiter.tokens > limiter.burst {
+		limiter.tokens = limiter.burst
+	}
+
+	if limiter.tokens < 1 {
+		return false
+	}
+	limiter.tokens--
+	return true
+}
+
+// checkOrigin returns true if the "Origin" header of the request is allowed by AppParams.AllowedOrigins.
+// Requests without the header are not sent by browsers, so they are allowed
+func (app *application) checkOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if len(app.params.AllowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, req.Host)
+	}
+
+	for _, allowed := range app.params.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxMessageSize returns the maximal size of a client message in bytes or 0 if the size is not limited
+func (app *application) maxMessageSize() int64 {
+	switch size := app.params.MaxMessageSize; {
+	case size == 0:
+		return DefaultMaxMessageSize
+
+	case size < 0:
+		return 0
+
+	default:
+		return size
+	}
+}
+
+// remoteHost returns the host part of the remote address (without the port)
+func remoteHost(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
+
+// tooManySessions returns true if the limit of concurrent sessions (AppParams.MaxSessionsPerAddress)
+// is reached for the remote address
+func (app *application) tooManySessions(address string) bool {
+	limit := app.params.MaxSessionsPerAddress
+	if limit <= 0 {
+		return false
+	}
+
+	host := remoteHost(address)
+	count := 0
+
+	app.sessionsMutex.RLock()
+	defer app.sessionsMutex.RUnlock()
+
+	for _, info := range app.sessions {
+		if info.host == host {
+			if count++; count >= limit {
+				ErrorLogF("The limit of sessions (%d) is reached for %s", limit, host)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sessionInfo returns the information about the session with the given id
+func (app *application) sessionInfo(sessionID int) (sessionInfo, bool) {
+	app.sessionsMutex.RLock()
+	defer app.sessionsMutex.RUnlock()
+
+	info, ok := app.sessions[sessionID]
+	return info, ok
+}
+
+// sessionList returns all sessions of the application
+func (app *application) sessionList() []sessionInfo {
+	app.sessionsMutex.RLock()
+	defer app.sessionsMutex.RUnlock()
+
+	result := make([]sessionInfo, 0, len(app.sessions))
+	for _, info := range app.sessions {
+		result = append(result, info)
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package rui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnectionLimits(t *testing.T) {
+	createTestLog(t, true)
+
+	app := createTestApp(t, AppParams{
+		EventRateLimit:        2,
+		EventRateBurst:        3,
+		MaxSessionsPerAddress: 1,
+	})
+
+	limiter := newEventRateLimiter(app.params.EventRateLimit, app.params.EventRateBurst)
+	for i := range 3 {
+		if !limiter.allow() {
+			t.Errorf("message %d is rejected within the burst", i+1)
+		}
+	}
+	if limiter.allow() {
+		t.Error("the message exceeding the burst is allowed")
+	}
+	if (*eventRateLimiter)(nil).allow() == false || newEventRateLimiter(0, 10) != nil {
+		t.Error("the rate must not be limited if EventRateLimit is 0")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/ws", nil)
+	for origin, expected := range map[string]bool{
+		"":                     true,
+		"http://example.com":   true,
+		"https://evil.example": false,
+	} {
+		req.Header.Set("Origin", origin)
+		if app.checkOrigin(req) != expected {
+			t.Errorf("checkOrigin(%q) = %v", origin, !expected)
+		}
+	}
+
+	app.params.AllowedOrigins = []string{"https://evil.example/"}
+	req.Header.Set("Origin", "https://evil.example")
+	if !app.checkOrigin(req) {
+		t.Error("the allowed origin is rejected")
+	}
+
+	recorder := httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("start-session{session=5}"))
+	req.Header.Set("Origin", "https://other.example")
+	app.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("status = %d, expected 403", recorder.Code)
+	}
+
+	bridge := createHttpBridge(httptest.NewRequest(http.MethodPost, "/", nil))
+	params, _ := ParseDataText("start-session{session=5}")
+	if app.createSession(params, nil, bridge, bridge.response, nil, "") == nil {
+		t.Fatal("the session is not created")
+	}
+	if !app.tooManySessions(bridge.remoteAddr()) {
+		t.Error("the limit of sessions per address is not applied")
+	}
+	if app.tooManySessions("10.0.0.1:1234") {
+		t.Error("the limit of sessions is applied to another address")
+	}
+}
+
+func TestCloseSessionOnExcess(t *testing.T) {
+	createTestLog(t, true)
+
+	app := createTestApp(t, AppParams{
+		EventRateLimit:  1,
+		EventRateBurst:  1,
+		RateLimitPolicy: CloseSessionOnExcess,
+		MaxMessageSize:  64,
+	})
+
+	post := func(body string) int {
+		recorder := httptest.NewRecorder()
+		app.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return recorder.Code
+	}
+
+	if code := post("start-session{session=7, data=\"" + strings.Repeat("a", 64) + "\"}"); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, expected 413", code)
+	}
+
+	if code := post("start-session{session=7}"); code != http.StatusOK {
+		t.Fatalf("start-session status = %d", code)
+	}
+	post("nop{session=7}")
+	if code := post("nop{session=7}"); code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, expected 429", code)
+	}
+
+	// the session is finished by its goroutine
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := app.sessionInfo(7); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the session is not finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
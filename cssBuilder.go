@@ -193,7 +193,6 @@ func (builder *cssStyleBuilder) startMedia(rule string) {
 	if builder.buffer == nil {
 		builder.init(0)
 	}
-	builder.buffer.WriteString(`@media screen`)
 	builder.buffer.WriteString(rule)
 	builder.buffer.WriteString(` {\n`)
 	builder.media = true
@@ -277,6 +276,40 @@ func (builder *cssStyleBuilder) endAnimationFrame() {
 	builder.buffer.WriteString(`\t}\n`)
 }
 
+// unescapeScriptCSS replaces the escape sequences of the script string literal (\n, \t, \', \", \\)
+// written by cssStyleBuilder with the characters. Other sequences (the CSS escapes) are kept
+func unescapeScriptCSS(css string) string {
+	if !strings.ContainsRune(css, '\\') {
+		return css
+	}
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	for i := 0; i < len(css); i++ {
+		if css[i] == '\\' && i+1 < len(css) {
+			switch css[i+1] {
+			case 'n':
+				buffer.WriteByte('\n')
+				i++
+				continue
+
+			case 't':
+				buffer.WriteByte('\t')
+				i++
+				continue
+
+			case '\'', '"', '\\':
+				buffer.WriteByte(css[i+1])
+				i++
+				continue
+			}
+		}
+		buffer.WriteByte(css[i])
+	}
+	return buffer.String()
+}
+
 func (builder *cssStyleBuilder) add(key, value string) {
 	if value != "" {
 		if builder.buffer == nil {
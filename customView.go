@@ -208,6 +208,10 @@ func (customView *CustomViewData) htmlTag() string {
 	return customView.superView.htmlTag()
 }
 
+func (customView *CustomViewData) htmlRole() string {
+	return customView.superView.htmlRole()
+}
+
 func (customView *CustomViewData) closeHTMLTag() bool {
 	return customView.superView.closeHTMLTag()
 }
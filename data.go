@@ -1,7 +1,6 @@
 package rui
 
 import (
-	"errors"
 	"fmt"
 	"iter"
 	"slices"
@@ -102,9 +101,90 @@ type DataNode interface {
 	ArrayAsParams() []Params
 }
 
+// DataPositioner is implemented by DataObject and DataNode values created by the parser.
+// Use DataPositionOf to get the position of any DataObject or DataNode
+type DataPositioner interface {
+	// Position returns the position in the source text.
+	// If the value was not created by the parser then the zero value is returned
+	Position() DataPosition
+}
+
+// DataPositionOf returns the position of the DataObject or DataNode in the source text.
+// The zero value is returned if the value does not implement DataPositioner
+func DataPositionOf(value any) DataPosition {
+	if positioner, ok := value.(DataPositioner); ok {
+		return positioner.Position()
+	}
+	return DataPosition{}
+}
+
+// DataPosition describes a position in the source text of a data
+type DataPosition struct {
+	// File is the name of the source file. May be empty
+	File string
+
+	// Line is the line number starting from 1. 0 means that the position is unknown
+	Line int
+
+	// Column is the column number (in characters) starting from 1
+	Column int
+}
+
+// IsValid returns "true" if the position is known
+func (pos DataPosition) IsValid() bool {
+	return pos.Line > 0
+}
+
+// String returns the text representation of the position in the format "file:line:column"
+func (pos DataPosition) String() string {
+	if !pos.IsValid() {
+		return pos.File
+	}
+	if pos.File == "" {
+		return fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", pos.File, pos.Line, pos.Column)
+}
+
+// DataError describes an error found in the text data: a syntax error returned by the parser
+// or an error found during the validation of a view description
+type DataError struct {
+	// Position is the position of the error in the source text
+	Position DataPosition
+
+	// Tag is the tag of the node (the view type or the property name) which caused the error. May be empty
+	Tag string
+
+	// Message is the description of the error
+	Message string
+}
+
+func (err *DataError) Error() string {
+	message := err.Message
+	if err.Tag != "" {
+		message = `"` + err.Tag + `": ` + message
+	}
+	if pos := err.Position.String(); pos != "" {
+		return pos + ": " + message
+	}
+	return message
+}
+
+// dataComments stores the comments of the source text which are bound to a node, an object or an array element.
+// An empty string in the "before" and "end" lists means an empty line.
+// The comments are stored only by the parser used by the formatter (see FormatDataText)
+type dataComments struct {
+	before []string // comments before a node/object/element
+	open   string   // comment on the same line after '{' or '['
+	end    []string // comments before '}' or ']'
+	after  string   // comment on the same line after a node/object/element
+	tail   []string // comments after the root object
+}
+
 /******************************************************************************/
 type dataStringValue struct {
-	value string
+	value    string
+	comments *dataComments
 }
 
 func (value *dataStringValue) Value() string {
@@ -121,8 +201,10 @@ func (value *dataStringValue) Object() DataObject {
 
 /******************************************************************************/
 type dataObject struct {
+	comments *dataComments
 	tag      string
 	property []DataNode
+	position DataPosition
 }
 
 // NewDataObject create new DataObject with the tag and empty property list
@@ -149,6 +231,10 @@ func (object *dataObject) Tag() string {
 	return object.tag
 }
 
+func (object *dataObject) Position() DataPosition {
+	return object.position
+}
+
 func (object *dataObject) Properties() iter.Seq[DataNode] {
 	return func(yield func(DataNode) bool) {
 		for _, node := range object.property {
@@ -296,15 +382,21 @@ func (object *dataObject) ToParams() Params {
 
 /******************************************************************************/
 type dataNode struct {
-	tag   string
-	value DataValue
-	array []DataValue
+	comments *dataComments
+	tag      string
+	value    DataValue
+	array    []DataValue
+	position DataPosition
 }
 
 func (node *dataNode) Tag() string {
 	return node.tag
 }
 
+func (node *dataNode) Position() DataPosition {
+	return node.position
+}
+
 func (node *dataNode) Type() DataNodeType {
 	if node.array != nil {
 		return ArrayNode
@@ -381,11 +473,64 @@ func (node *dataNode) ArrayAsParams() []Params {
 }
 
 type dataParser struct {
+	file      string
 	data      []rune
 	size      int
 	pos       int
 	line      int
 	lineStart int
+	// keepComments - if true then the comments and the empty lines are stored in the nodes (see dataComments)
+	keepComments bool
+	comments     []string
+	trailing     *string
+}
+
+func (parser *dataParser) position() DataPosition {
+	return DataPosition{
+		File:   parser.file,
+		Line:   parser.line,
+		Column: parser.pos - parser.lineStart + 1,
+	}
+}
+
+func (parser *dataParser) error(message string) error {
+	return &DataError{Position: parser.position(), Message: message}
+}
+
+// newComments returns the comments of a new node with the comments collected before it
+// or nil if the comments are not kept
+func (parser *dataParser) newComments() *dataComments {
+	if !parser.keepComments {
+		return nil
+	}
+	return &dataComments{before: parser.takeComments()}
+}
+
+func (parser *dataParser) takeComments() []string {
+	result := parser.comments
+	parser.comments = nil
+	return result
+}
+
+func (parser *dataParser) isEmptyLine() bool {
+	for _, ch := range parser.data[parser.lineStart:parser.pos] {
+		if ch != ' ' && ch != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+func (parser *dataParser) addComment(start int, sameLine bool) {
+	if !parser.keepComments {
+		return
+	}
+	comment := string(parser.data[start : parser.pos+1])
+	if sameLine && parser.trailing != nil && *parser.trailing == "" {
+		*parser.trailing = comment
+	} else {
+		parser.comments = append(parser.comments, comment)
+	}
 }
 
 func (parser *dataParser) skipSpaces(skipNewLine bool) {
@@ -395,11 +540,18 @@ func (parser *dataParser) skipSpaces(skipNewLine bool) {
 			if !skipNewLine {
 				return
 			}
+			if parser.keepComments && parser.isEmptyLine() {
+				if count := len(parser.comments); count == 0 || parser.comments[count-1] != "" {
+					parser.comments = append(parser.comments, "")
+				}
+			}
 			parser.line++
 			parser.lineStart = parser.pos + 1
 
 		case '/':
 			if parser.pos+1 < parser.size {
+				start := parser.pos
+				sameLine := !parser.isEmptyLine()
 				switch parser.data[parser.pos+1] {
 				case '/':
 					parser.pos += 2
@@ -407,6 +559,7 @@ func (parser *dataParser) skipSpaces(skipNewLine bool) {
 						parser.pos++
 					}
 					parser.pos--
+					parser.addComment(start, sameLine)
 
 				case '*':
 					parser.pos += 3
@@ -424,6 +577,7 @@ func (parser *dataParser) skipSpaces(skipNewLine bool) {
 						}
 						parser.pos++
 					}
+					parser.addComment(start, sameLine)
 
 				default:
 					return
@@ -450,13 +604,18 @@ func (parser *dataParser) parseTag() (string, error) {
 		parser.pos++
 		startPos++
 		for parser.data[parser.pos] != '`' {
+			if parser.data[parser.pos] == '\n' {
+				parser.line++
+				parser.lineStart = parser.pos + 1
+			}
 			parser.pos++
 			if parser.pos >= parser.size {
-				return string(parser.data[startPos:parser.size]), errors.New("unexpected end of text")
+				return string(parser.data[startPos:parser.size]), parser.error("unexpected end of text")
 			}
 		}
 		str := string(parser.data[startPos:parser.pos])
 		parser.pos++
+		parser.skipSpaces(false)
 		return str, nil
 
 	case '\'', '"':
@@ -472,7 +631,7 @@ func (parser *dataParser) parseTag() (string, error) {
 				parser.pos++
 			}
 			if parser.pos >= parser.size {
-				return string(parser.data[startPos:parser.size]), errors.New("unexpected end of text")
+				return string(parser.data[startPos:parser.size]), parser.error("unexpected end of text")
 			}
 		}
 
@@ -490,7 +649,7 @@ func (parser *dataParser) parseTag() (string, error) {
 		invalidEscape := func() (string, error) {
 			str := string(parser.data[startPos:parser.pos])
 			parser.pos++
-			return str, fmt.Errorf(`invalid escape sequence in "%s" (position %d)`, str, n2-2-startPos)
+			return str, parser.error(fmt.Sprintf(`invalid escape sequence in "%s" (position %d)`, str, n2-2-startPos))
 		}
 
 		for n2 < parser.pos {
@@ -560,7 +719,7 @@ func (parser *dataParser) parseTag() (string, error) {
 
 				default:
 					str := string(parser.data[startPos:parser.pos])
-					return str, fmt.Errorf(`invalid escape sequence in "%s" (position %d)`, str, n2-2-startPos)
+					return str, parser.error(fmt.Sprintf(`invalid escape sequence in "%s" (position %d)`, str, n2-2-startPos))
 				}
 			}
 			n1++
@@ -590,52 +749,50 @@ func (parser *dataParser) stopSymbol(symbol rune) bool {
 }
 
 func (parser *dataParser) parseNode() (DataNode, error) {
-	var tag string
 	var err error
 
-	if tag, err = parser.parseTag(); err != nil {
+	parser.skipSpaces(true)
+	node := &dataNode{position: parser.position(), comments: parser.newComments()}
+	if node.comments != nil {
+		parser.trailing = &node.comments.after
+	}
+
+	if node.tag, err = parser.parseTag(); err != nil {
 		return nil, err
 	}
 
 	parser.skipSpaces(true)
 	if parser.data[parser.pos] != '=' {
-		return nil, fmt.Errorf("expected '=' after a tag name (line: %d, position: %d)", parser.line, parser.pos-parser.lineStart)
+		return nil, parser.error("expected '=' after a tag name")
 	}
 
 	parser.pos++
 	parser.skipSpaces(true)
 	switch parser.data[parser.pos] {
 	case '[':
-		node := new(dataNode)
-		node.tag = tag
-
-		if node.array, err = parser.parseArray(); err != nil {
+		if err = parser.parseArray(node); err != nil {
 			return nil, err
 		}
 		return node, nil
 
 	case '{':
-		node := new(dataNode)
-		node.tag = tag
-		if node.value, err = parser.parseObject("_"); err != nil {
+		if node.value, err = parser.parseObject("_", parser.position()); err != nil {
 			return nil, err
 		}
 		return node, nil
 
 	case '}', ']', '=':
-		return nil, fmt.Errorf(`expected '[', '{' or a tag name after '=' (line: %d, position: %d)`, parser.line, parser.pos-parser.lineStart)
+		return nil, parser.error(`expected '[', '{' or a tag name after '='`)
 
 	default:
 		var str string
+		objectPosition := parser.position()
 		if str, err = parser.parseTag(); err != nil {
 			return nil, err
 		}
 
-		node := new(dataNode)
-		node.tag = tag
-
 		if parser.data[parser.pos] == '{' {
-			if node.value, err = parser.parseObject(str); err != nil {
+			if node.value, err = parser.parseObject(str, objectPosition); err != nil {
 				return nil, err
 			}
 		} else {
@@ -648,19 +805,27 @@ func (parser *dataParser) parseNode() (DataNode, error) {
 	}
 }
 
-func (parser *dataParser) parseObject(tag string) (DataObject, error) {
+func (parser *dataParser) parseObject(tag string, position DataPosition) (DataObject, error) {
 	if parser.data[parser.pos] != '{' {
-		return nil, fmt.Errorf(`expected '{' (line: %d, position: %d)`, parser.line, parser.pos-parser.lineStart)
+		return nil, parser.error(`expected '{'`)
 	}
 	parser.pos++
 
 	obj := new(dataObject)
 	obj.tag = tag
 	obj.property = []DataNode{}
+	obj.position = position
+	if obj.comments = parser.newComments(); obj.comments != nil {
+		parser.trailing = &obj.comments.open
+	}
 
 	for parser.pos < parser.size {
 		parser.skipSpaces(true)
 		if parser.data[parser.pos] == '}' {
+			if obj.comments != nil {
+				obj.comments.end = parser.takeComments()
+				parser.trailing = &obj.comments.after
+			}
 			parser.pos++
 			parser.skipSpaces(false)
 			return obj, nil
@@ -673,11 +838,14 @@ func (parser *dataParser) parseObject(tag string) (DataObject, error) {
 
 		obj.property = append(obj.property, node)
 		if parser.data[parser.pos] == '}' {
+			if obj.comments != nil {
+				parser.trailing = &obj.comments.after
+			}
 			parser.pos++
 			parser.skipSpaces(true)
 			return obj, nil
 		} else if parser.data[parser.pos] != ',' && parser.data[parser.pos] != '\n' {
-			return nil, fmt.Errorf(`expected '}', '\n' or ',' (line: %d, position: %d)`, parser.line, parser.pos-parser.lineStart)
+			return nil, parser.error(`expected '}', '\n' or ','`)
 		}
 
 		if parser.data[parser.pos] != '\n' {
@@ -691,14 +859,17 @@ func (parser *dataParser) parseObject(tag string) (DataObject, error) {
 		}
 	}
 
-	return nil, errors.New("unexpected end of text")
+	return nil, parser.error("unexpected end of text")
 }
 
-func (parser *dataParser) parseArray() ([]DataValue, error) {
+func (parser *dataParser) parseArray(node *dataNode) error {
 	parser.pos++
+	if node.comments != nil {
+		parser.trailing = &node.comments.open
+	}
 	parser.skipSpaces(true)
 
-	array := []DataValue{}
+	node.array = []DataValue{}
 
 	for parser.pos < parser.size {
 		parser.skipSpaces(true)
@@ -712,53 +883,69 @@ func (parser *dataParser) parseArray() ([]DataValue, error) {
 		}
 
 		if parser.data[parser.pos] == ']' {
+			if node.comments != nil {
+				node.comments.end = parser.takeComments()
+				parser.trailing = &node.comments.after
+			}
 			parser.pos++
-			parser.skipSpaces(true)
-			return array, nil
+			parser.skipSpaces(false)
+			return nil
 		}
 
+		position := parser.position()
+		comments := parser.takeComments()
+		parser.trailing = nil
 		tag, err := parser.parseTag()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		if parser.data[parser.pos] == '{' {
-			obj, err := parser.parseObject(tag)
+			obj, err := parser.parseObject(tag, position)
 			if err != nil {
-				return nil, err
+				return err
+			}
+			if data := obj.(*dataObject); data.comments != nil {
+				data.comments.before = comments
 			}
-			array = append(array, obj)
+			node.array = append(node.array, obj)
 		} else {
 			val := new(dataStringValue)
 			val.value = tag
-			array = append(array, val)
+			if parser.keepComments {
+				val.comments = &dataComments{before: comments}
+				parser.trailing = &val.comments.after
+			}
+			node.array = append(node.array, val)
 		}
 
 		switch parser.data[parser.pos] {
 		case ']', ',', '\n':
 
 		default:
-			return nil, fmt.Errorf(`expected ']' or ',' (line: %d, position: %d)`, parser.line, parser.pos-parser.lineStart)
+			return parser.error(`expected ']' or ','`)
 		}
-
-		/*
-			if data[pos] == ']' {
-				pos++
-				skipSpaces()
-				return array, nil
-			} else if data[pos] != ',' {
-				return nil, fmt.Errorf("Expected ']' or ',' (line: %d, position: %d)", line, pos-lineStart)
-			}
-			pos++
-			skipSpaces()
-		*/
 	}
 
-	return nil, errors.New("unexpected end of text")
+	return parser.error("unexpected end of text")
 }
 
-// ParseDataText - parse text and return DataNode
+// ParseDataText - parse text and return DataNode.
+// If the text contains a syntax error then *DataError is returned
 func ParseDataText(text string) (DataObject, error) {
+	return parseDataFile("", text, false)
+}
+
+// ParseDataFile parses the text of the file with the given name and returns DataNode.
+// The file name is used only for the positions of nodes and for error messages.
+// If the text contains a syntax error then *DataError is returned
+func ParseDataFile(filename, text string) (DataObject, error) {
+	return parseDataFile(filename, text, false)
+}
+
+// parseDataFile parses the text. If keepComments is true then the comments and the empty lines
+// are stored in the nodes for the formatter (see FormatDataText)
+func parseDataFile(filename, text string, keepComments bool) (DataObject, error) {
 
 	if strings.ContainsAny(text, "\r") {
 		text = strings.ReplaceAll(text, "\r\n", "\n")
@@ -766,16 +953,34 @@ func ParseDataText(text string) (DataObject, error) {
 	}
 
 	parser := dataParser{
-		data:      append([]rune(text), rune(0)),
-		pos:       0,
-		line:      1,
-		lineStart: 0,
+		file:         filename,
+		data:         append([]rune(text), rune(0)),
+		pos:          0,
+		line:         1,
+		lineStart:    0,
+		keepComments: keepComments,
 	}
 	parser.size = len(parser.data) - 1
 
+	parser.skipSpaces(true)
+	position := parser.position()
+	comments := parser.takeComments()
 	tag, err := parser.parseTag()
 	if err != nil {
 		return nil, err
 	}
-	return parser.parseObject(tag)
+
+	obj, err := parser.parseObject(tag, position)
+	if err != nil {
+		return nil, err
+	}
+
+	root := obj.(*dataObject)
+	if root.comments != nil {
+		root.comments.before = comments
+		parser.trailing = &root.comments.after
+		parser.skipSpaces(true)
+		root.comments.tail = parser.takeComments()
+	}
+	return root, nil
 }
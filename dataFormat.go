@@ -0,0 +1,129 @@
+package rui
+
+// FormatDataText parses the text of the file with the given name and returns it in the canonical format
+// (see FormatDataObject). Unlike FormatDataObject, the comments and the empty lines between properties are preserved.
+// If the text contains a syntax error then *DataError is returned
+func FormatDataText(filename, text string) (string, error) {
+	obj, err := parseDataFile(filename, text, true)
+	if err != nil {
+		return "", err
+	}
+	return FormatDataObject(obj), nil
+}
+
+// FormatDataObject returns the canonical text representation of the DataObject.
+//
+// Canonical format rules: one property per line, the tab indentation, " = " between a tag and a value,
+// a comma after each property and array element, and short arrays of text values are written in one line.
+func FormatDataObject(obj DataObject) string {
+	if obj == nil {
+		return ""
+	}
+
+	writer := newRUIWriter()
+	writeDataObject(writer, obj)
+	writer.writeComments(dataObjectComments(obj).tail)
+	return writer.finish()
+}
+
+var noDataComments = &dataComments{}
+
+func (comments *dataComments) hasComments() bool {
+	return len(comments.before) > 0 || comments.after != ""
+}
+
+func dataObjectComments(obj DataObject) *dataComments {
+	if data, ok := obj.(*dataObject); ok && data.comments != nil {
+		return data.comments
+	}
+	return noDataComments
+}
+
+func dataNodeComments(node DataNode) *dataComments {
+	if data, ok := node.(*dataNode); ok && data.comments != nil {
+		return data.comments
+	}
+	return noDataComments
+}
+
+func dataValueComments(value DataValue) *dataComments {
+	if data, ok := value.(*dataStringValue); ok && data.comments != nil {
+		return data.comments
+	}
+	return noDataComments
+}
+
+// writeDataObject writes the root object or the object element of an array
+func writeDataObject(writer ruiWriter, obj DataObject) {
+	comments := dataObjectComments(obj)
+	writer.writeComments(comments.before)
+	writer.startObject(obj.Tag())
+	writeDataProperties(writer, obj)
+	writer.endObject()
+	writer.writeTrailingComment(comments.after)
+}
+
+func writeDataProperties(writer ruiWriter, obj DataObject) {
+	comments := dataObjectComments(obj)
+	writer.writeTrailingComment(comments.open)
+
+	for node := range obj.Properties() {
+		nodeComments := dataNodeComments(node)
+		writer.writeComments(nodeComments.before)
+
+		switch node.Type() {
+		case ObjectNode:
+			value := node.Object()
+			writer.startObjectProperty(node.Tag(), value.Tag())
+			writeDataProperties(writer, value)
+			writer.endObject()
+			writer.writeTrailingComment(dataObjectComments(value).after)
+
+		case ArrayNode:
+			writeDataArray(writer, node)
+
+		default:
+			writer.writeProperty(node.Tag(), node.Text())
+		}
+
+		writer.writeTrailingComment(nodeComments.after)
+	}
+
+	writer.writeComments(comments.end)
+}
+
+func writeDataArray(writer ruiWriter, node DataNode) {
+	comments := dataNodeComments(node)
+	if comments.open == "" && len(comments.end) == 0 {
+		values := []string{}
+		for value := range node.ArrayElements() {
+			if value.IsObject() || dataValueComments(value).hasComments() {
+				values = nil
+				break
+			}
+			values = append(values, value.Value())
+		}
+
+		if values != nil {
+			writer.writeProperty(node.Tag(), values)
+			return
+		}
+	}
+
+	writer.startArrayProperty(node.Tag())
+	writer.writeTrailingComment(comments.open)
+
+	for value := range node.ArrayElements() {
+		if value.IsObject() {
+			writeDataObject(writer, value.Object())
+		} else {
+			valueComments := dataValueComments(value)
+			writer.writeComments(valueComments.before)
+			writer.writeArrayElement(value.Value())
+			writer.writeTrailingComment(valueComments.after)
+		}
+	}
+
+	writer.writeComments(comments.end)
+	writer.endObArray()
+}
@@ -0,0 +1,105 @@
+package rui
+
+import (
+	"testing"
+)
+
+func TestFormatDataText(t *testing.T) {
+	text := `// header
+View {
+	width=100%, // width
+  content = [ TextView{text="Hello world"}, "Button"  ],
+
+	// empty lines are kept
+	tags=[a,b],
+}
+// footer
+`
+
+	expected := `// header
+View {
+	width = 100%, // width
+	content = [
+		TextView {
+			text = "Hello world",
+		},
+		Button,
+	],
+
+	// empty lines are kept
+	tags = [a, b],
+}
+// footer
+`
+
+	result, err := FormatDataText("test.rui", text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != expected {
+		t.Errorf("FormatDataText result:\n%s", result)
+	}
+
+	if again, err := FormatDataText("test.rui", result); err != nil {
+		t.Fatal(err)
+	} else if again != result {
+		t.Errorf("FormatDataText is not idempotent:\n%s", again)
+	}
+
+	if _, err := FormatDataText("test.rui", "View {\n\twidth 100%\n}"); err == nil {
+		t.Error("FormatDataText must fail")
+	} else if dataErr, ok := err.(*DataError); !ok || dataErr.Position.String() != "test.rui:2:8" {
+		t.Errorf("FormatDataText error: %v", err)
+	}
+}
+
+func TestFormatDataArrayComments(t *testing.T) {
+	texts := map[string]string{
+		"View {\n\ttags = [ // first\n a, // second\n b ]\n}": `View {
+	tags = [ // first
+		a, // second
+		b,
+	],
+}
+`,
+		"View {\n\ttags = [\n // first\n a,\n // second\n b ]\n}": `View {
+	tags = [
+		// first
+		a,
+		// second
+		b,
+	],
+}
+`,
+	}
+
+	for text, expected := range texts {
+		result, err := FormatDataText("test.rui", text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result != expected {
+			t.Errorf("FormatDataText result:\n%s", result)
+		}
+
+		if again, err := FormatDataText("test.rui", result); err != nil {
+			t.Fatal(err)
+		} else if again != result {
+			t.Errorf("FormatDataText is not idempotent:\n%s", again)
+		}
+	}
+}
+
+func TestFormatDataObject(t *testing.T) {
+	obj, err := ParseDataText("// header\nView { width=100%, /* comment */ tags=[a,b] }")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "View {\n\twidth = 100%,\n\ttags = [a, b],\n}\n"
+	if result := FormatDataObject(obj); result != expected {
+		t.Errorf("FormatDataObject result:\n%s", result)
+	}
+}
@@ -0,0 +1,537 @@
+package rui
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// UnmarshalError describes an error that occurred while unmarshaling a DataObject into a Go value.
+// Position is the position of the property in the source text (the zero value if the DataObject
+// was not created by the parser).
+type UnmarshalError struct {
+	// Tag is the tag of the property that caused the error. The tag of a nested property
+	// is prefixed by the path of the parent properties ("items[1].value")
+	Tag string
+
+	// Position is the position of the property in the source text
+	Position DataPosition
+
+	// Err is the underlying error
+	Err error
+}
+
+func (err *UnmarshalError) Error() string {
+	if pos := err.Position.String(); pos != "" {
+		return fmt.Sprintf(`%s: "%s": %s`, pos, err.Tag, err.Err.Error())
+	}
+	return fmt.Sprintf(`"%s": %s`, err.Tag, err.Err.Error())
+}
+
+func (err *UnmarshalError) Unwrap() error {
+	return err.Err
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	colorType      = reflect.TypeFor[Color]()
+	sizeUnitType   = reflect.TypeFor[SizeUnit]()
+	angleUnitType  = reflect.TypeFor[AngleUnit]()
+	dataObjectType = reflect.TypeFor[DataObject]()
+
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// Unmarshal stores the content of the DataObject in the value pointed to by v.
+// v must be a non-nil pointer to a struct.
+//
+// The properties of the DataObject are matched to the struct fields by the "rui" field tag:
+//
+//	type Config struct {
+//		Title   string    `rui:"title"`
+//		Color   Color     `rui:"color"`
+//		Padding SizeUnit  `rui:"padding,omitempty"`
+//		Items   []Item    `rui:"items"`
+//		Ignored int       `rui:"-"`
+//	}
+//
+// If the tag is not set then the field name converted to kebab-case is used ("TextColor" -> "text-color").
+// Supported field types are: string, bool, integers, floats, time.Time (RFC 3339 or "2006-01-02" format),
+// Color, SizeUnit, AngleUnit, DataObject, structs, pointers, slices, maps with string keys
+// and types implementing encoding.TextUnmarshaler.
+func Unmarshal(obj DataObject, v any) error {
+	if obj == nil {
+		return errors.New("Unmarshal: DataObject is nil")
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("Unmarshal: the argument must be a non-nil pointer")
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("Unmarshal: unsupported type %s", rv.Type().String())
+	}
+
+	return unmarshalObject(obj, rv)
+}
+
+// Marshal returns the DataObject representation of v. v must be a struct or a pointer to a struct.
+// The object tag is the name of the struct type. The rules of converting fields are the same as for [Unmarshal].
+// Fields with the "omitempty" option are skipped if they have a zero value.
+func Marshal(v any) DataObject {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		ErrorLogF(`Marshal: unsupported type %s`, rv.Type().String())
+		return nil
+	}
+
+	return marshalObject(rv.Type().Name(), rv)
+}
+
+type marshalField struct {
+	index     int
+	tag       string
+	omitEmpty bool
+}
+
+func marshalFields(structType reflect.Type) []marshalField {
+	result := []marshalField{}
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, options, _ := strings.Cut(field.Tag.Get("rui"), ",")
+		if tag == "-" && options == "" {
+			continue
+		}
+
+		if tag == "" {
+			tag = kebabCase(field.Name)
+		}
+
+		result = append(result, marshalField{
+			index:     i,
+			tag:       tag,
+			omitEmpty: options == "omitempty",
+		})
+	}
+	return result
+}
+
+func kebabCase(name string) string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	runes := []rune(name)
+	for i, ch := range runes {
+		if unicode.IsUpper(ch) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				buffer.WriteRune('-')
+			}
+			buffer.WriteRune(unicode.ToLower(ch))
+		} else {
+			buffer.WriteRune(ch)
+		}
+	}
+	return buffer.String()
+}
+
+// newUnmarshalError returns the error of the node. If err is the error of a nested property
+// then the tag of the node is added to the path of the property ("parent.child")
+func newUnmarshalError(node DataNode, err error) error {
+	if inner, ok := err.(*UnmarshalError); ok {
+		result := &UnmarshalError{Tag: node.Tag(), Position: inner.Position, Err: inner.Err}
+		if strings.HasPrefix(inner.Tag, "[") {
+			result.Tag += inner.Tag
+		} else {
+			result.Tag += "." + inner.Tag
+		}
+		if !result.Position.IsValid() {
+			result.Position = DataPositionOf(node)
+		}
+		return result
+	}
+
+	return &UnmarshalError{Tag: node.Tag(), Position: DataPositionOf(node), Err: err}
+}
+
+// elementError returns the error of the array element. The index of the element is added
+// to the path of the nested property ("[index].child")
+func elementError(index int, err error) error {
+	if inner, ok := err.(*UnmarshalError); ok {
+		return &UnmarshalError{Tag: fmt.Sprintf("[%d].%s", index, inner.Tag), Position: inner.Position, Err: inner.Err}
+	}
+	return fmt.Errorf("element %d: %w", index, err)
+}
+
+func unmarshalObject(obj DataObject, rv reflect.Value) error {
+	for _, field := range marshalFields(rv.Type()) {
+		if node := obj.PropertyByTag(field.tag); node != nil {
+			if err := unmarshalNode(node, rv.Field(field.index)); err != nil {
+				return newUnmarshalError(node, err)
+			}
+		}
+	}
+	return nil
+}
+
+func unmarshalNode(node DataNode, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if !reflect.PointerTo(rv.Type().Elem()).Implements(textUnmarshalerType) {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			return unmarshalNode(node, rv.Elem())
+		}
+
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+
+		elements := []DataValue{}
+		for element := range node.ArrayElements() {
+			if element != nil {
+				elements = append(elements, element)
+			}
+		}
+
+		slice := reflect.MakeSlice(rv.Type(), len(elements), len(elements))
+		for i, element := range elements {
+			if err := unmarshalValue(element, slice.Index(i)); err != nil {
+				return elementError(i, err)
+			}
+		}
+		rv.Set(slice)
+		return nil
+
+	case reflect.Array:
+		count := 0
+		for element := range node.ArrayElements() {
+			if count >= rv.Len() {
+				return fmt.Errorf("too many elements, expected %d", rv.Len())
+			}
+			if err := unmarshalValue(element, rv.Index(count)); err != nil {
+				return elementError(count, err)
+			}
+			count++
+		}
+		return nil
+	}
+
+	if node.Type() == ArrayNode {
+		return fmt.Errorf("an array can not be assigned to a value of %s type", rv.Type().String())
+	}
+
+	if node.Type() == ObjectNode {
+		return unmarshalValue(node.Object(), rv)
+	}
+
+	return unmarshalText(node.Text(), rv)
+}
+
+func unmarshalValue(value DataValue, rv reflect.Value) error {
+	if value == nil {
+		return nil
+	}
+
+	if !value.IsObject() {
+		return unmarshalText(value.Value(), rv)
+	}
+
+	obj := value.Object()
+	if rv.Type() == dataObjectType {
+		rv.Set(reflect.ValueOf(obj))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return unmarshalValue(value, rv.Elem())
+
+	case reflect.Struct:
+		if rv.Type() != timeType && rv.Type() != sizeUnitType && rv.Type() != angleUnitType {
+			return unmarshalObject(obj, rv)
+		}
+
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(rv.Type()))
+		}
+		for node := range obj.Properties() {
+			item := reflect.New(rv.Type().Elem()).Elem()
+			if err := unmarshalNode(node, item); err != nil {
+				return newUnmarshalError(node, err)
+			}
+			rv.SetMapIndex(reflect.ValueOf(node.Tag()).Convert(rv.Type().Key()), item)
+		}
+		return nil
+
+	case reflect.Interface:
+		if rv.NumMethod() == 0 {
+			rv.Set(reflect.ValueOf(obj))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("an object can not be assigned to a value of %s type", rv.Type().String())
+}
+
+func unmarshalText(text string, rv reflect.Value) error {
+	switch rv.Type() {
+	case timeType:
+		for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+			if t, err := time.Parse(layout, text); err == nil {
+				rv.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf(`invalid time value "%s"`, text)
+
+	case colorType:
+		color, err := stringToColor(text)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(color))
+		return nil
+
+	case sizeUnitType:
+		size, err := stringToSizeUnit(text)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(size))
+		return nil
+
+	case angleUnitType:
+		angle, err := stringToAngleUnit(text)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(angle))
+		return nil
+	}
+
+	if rv.CanAddr() {
+		if unmarshaler, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler.UnmarshalText([]byte(text))
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(text)
+
+	case reflect.Bool:
+		switch strings.ToLower(strings.Trim(text, " \t")) {
+		case "true", "yes", "on", "1":
+			rv.SetBool(true)
+
+		case "false", "no", "off", "0":
+			rv.SetBool(false)
+
+		default:
+			return fmt.Errorf(`invalid bool value "%s"`, text)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.Trim(text, " \t"), 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(strings.Trim(text, " \t"), 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.Trim(text, " \t"), rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("a text can not be assigned to a value of %s type", rv.Type().String())
+		}
+		rv.SetBytes([]byte(text))
+
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return unmarshalText(text, rv.Elem())
+
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			return fmt.Errorf("a text can not be assigned to a value of %s type", rv.Type().String())
+		}
+		rv.Set(reflect.ValueOf(text))
+
+	default:
+		return fmt.Errorf("a text can not be assigned to a value of %s type", rv.Type().String())
+	}
+
+	return nil
+}
+
+func marshalObject(tag string, rv reflect.Value) DataObject {
+	obj := new(dataObject)
+	obj.tag = tag
+	obj.property = []DataNode{}
+
+	for _, field := range marshalFields(rv.Type()) {
+		value := rv.Field(field.index)
+		if field.omitEmpty && value.IsZero() {
+			continue
+		}
+		if node := marshalNode(field.tag, value); node != nil {
+			obj.property = append(obj.property, node)
+		}
+	}
+	return obj
+}
+
+func marshalNode(tag string, rv reflect.Value) DataNode {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil
+		}
+
+		node := &dataNode{tag: tag, array: []DataValue{}}
+		for i := range rv.Len() {
+			if value := marshalValue(rv.Index(i)); value != nil {
+				node.array = append(node.array, value)
+			}
+		}
+		return node
+	}
+
+	if value := marshalValue(rv); value != nil {
+		return &dataNode{tag: tag, value: value}
+	}
+	return nil
+}
+
+func marshalValue(rv reflect.Value) DataValue {
+	if rv.Type() == dataObjectType {
+		if rv.IsNil() {
+			return nil
+		}
+		return rv.Interface().(DataObject)
+	}
+
+	switch rv.Type() {
+	case timeType:
+		return &dataStringValue{value: rv.Interface().(time.Time).Format(time.RFC3339Nano)}
+
+	case colorType:
+		return &dataStringValue{value: rv.Interface().(Color).String()}
+
+	case sizeUnitType:
+		return &dataStringValue{value: rv.Interface().(SizeUnit).String()}
+
+	case angleUnitType:
+		return &dataStringValue{value: rv.Interface().(AngleUnit).String()}
+	}
+
+	if marshaler, ok := rv.Interface().(encoding.TextMarshaler); ok {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil
+		}
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			ErrorLog(err.Error())
+			return nil
+		}
+		return &dataStringValue{value: string(text)}
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return &dataStringValue{value: rv.String()}
+
+	case reflect.Bool:
+		return &dataStringValue{value: strconv.FormatBool(rv.Bool())}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &dataStringValue{value: strconv.FormatInt(rv.Int(), 10)}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &dataStringValue{value: strconv.FormatUint(rv.Uint(), 10)}
+
+	case reflect.Float32, reflect.Float64:
+		return &dataStringValue{value: strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())}
+
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return &dataStringValue{value: string(rv.Bytes())}
+		}
+
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return marshalValue(rv.Elem())
+
+	case reflect.Struct:
+		return marshalObject(rv.Type().Name(), rv)
+
+	case reflect.Map:
+		if rv.IsNil() || rv.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+
+		obj := new(dataObject)
+		obj.tag = "_"
+		obj.property = []DataNode{}
+
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, key := range keys {
+			if node := marshalNode(key.String(), rv.MapIndex(key)); node != nil {
+				obj.property = append(obj.property, node)
+			}
+		}
+		return obj
+	}
+
+	ErrorLogF(`Marshal: unsupported type %s`, rv.Type().String())
+	return nil
+}
@@ -0,0 +1,120 @@
+package rui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnmarshal(t *testing.T) {
+	type item struct {
+		Name  string
+		Value int `rui:"val"`
+	}
+
+	type config struct {
+		Title     string
+		TextColor Color
+		Padding   SizeUnit
+		Angle     AngleUnit
+		Date      time.Time
+		Enabled   bool
+		Ratio     float64
+		Tags      []string
+		Items     []item
+		Main      *item
+		Labels    map[string]string
+		Skip      string `rui:"-"`
+	}
+
+	text := `config {
+	title = "Test config",
+	text-color = #FF102030,
+	padding = 8px,
+	angle = 90deg,
+	date = 2024-05-17,
+	enabled = true,
+	ratio = 0.5,
+	tags = [a, b, c],
+	items = [ item { name = first, val = 1 }, item { name = second, val = 2 } ],
+	main = _{ name = main, val = 3 },
+	labels = _{ one = "1", two = "2" },
+	skip = skipped,
+}`
+
+	obj, err := ParseDataText(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg config
+	if err := Unmarshal(obj, &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Title != "Test config" || cfg.TextColor != 0xFF102030 || !cfg.Padding.Equal(Px(8)) ||
+		!cfg.Angle.Equal(Deg(90)) || cfg.Date.Year() != 2024 || !cfg.Enabled || cfg.Ratio != 0.5 || cfg.Skip != "" {
+		t.Errorf("Unmarshal result: %+v", cfg)
+	}
+
+	if len(cfg.Tags) != 3 || cfg.Tags[2] != "c" {
+		t.Errorf("Unmarshal tags: %v", cfg.Tags)
+	}
+
+	if len(cfg.Items) != 2 || cfg.Items[1].Name != "second" || cfg.Items[1].Value != 2 {
+		t.Errorf("Unmarshal items: %v", cfg.Items)
+	}
+
+	if cfg.Main == nil || cfg.Main.Value != 3 {
+		t.Errorf("Unmarshal main: %v", cfg.Main)
+	}
+
+	if cfg.Labels["two"] != "2" {
+		t.Errorf("Unmarshal labels: %v", cfg.Labels)
+	}
+
+	obj2 := Marshal(cfg)
+	var cfg2 config
+	if err := Unmarshal(obj2, &cfg2); err != nil {
+		t.Fatal(err)
+	}
+	if cfg2.Title != cfg.Title || cfg2.TextColor != cfg.TextColor || !cfg2.Date.Equal(cfg.Date) ||
+		len(cfg2.Items) != 2 || cfg2.Main == nil || cfg2.Main.Name != "main" || cfg2.Labels["one"] != "1" {
+		t.Errorf("Marshal/Unmarshal result: %+v", cfg2)
+	}
+
+	obj, err = ParseDataText("config {\n\ttitle = test,\n\tratio = abc\n}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Unmarshal(obj, &cfg)
+	if unmarshalErr, ok := err.(*UnmarshalError); !ok {
+		t.Errorf("Unmarshal error: %v", err)
+	} else if unmarshalErr.Tag != "ratio" || unmarshalErr.Position.Line != 3 || unmarshalErr.Position.Column != 2 {
+		t.Errorf("Unmarshal error position: %v", unmarshalErr)
+	}
+
+	obj, err = ParseDataText("config {\n\ttitle = \"010\",\n\titems = [ item { name = a, val = 010 }, item {\n\t\tname = b,\n\t\tval = 0x10 } ]\n}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Unmarshal(obj, &cfg)
+	if unmarshalErr, ok := err.(*UnmarshalError); !ok {
+		t.Errorf("Unmarshal error: %v", err)
+	} else if unmarshalErr.Tag != "items[1].val" || unmarshalErr.Position.Line != 5 {
+		t.Errorf("Unmarshal error path: %v", unmarshalErr)
+	}
+
+	obj, err = ParseDataText("item { name = a, val = 010 }")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decimal item
+	if err := Unmarshal(obj, &decimal); err != nil {
+		t.Error(err)
+	} else if decimal.Value != 10 {
+		t.Errorf(`"010" is unmarshaled as %d`, decimal.Value)
+	}
+}
@@ -204,3 +204,32 @@ func TestParseDataText(t *testing.T) {
 		}
 	}
 }
+
+func TestParseDataFileErrors(t *testing.T) {
+	_, err := ParseDataFile("test.rui", "obj {\n\tkey1 = value,\n\tkey2 value\n}")
+	if dataErr, ok := err.(*DataError); !ok {
+		t.Errorf("ParseDataFile error: %v", err)
+	} else if dataErr.Position.File != "test.rui" || dataErr.Position.Line != 3 {
+		t.Errorf("ParseDataFile error position: %s", dataErr.Error())
+	}
+
+	obj, err := ParseDataFile("test.rui", "obj {\n\tkey1 = value,\n  key2 = item { }\n}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pos := DataPositionOf(obj); pos.Line != 1 || pos.Column != 1 {
+		t.Errorf("DataPositionOf(obj) = %s", pos.String())
+	}
+
+	if node := obj.PropertyByTag("key2"); node == nil {
+		t.Error(`obj.PropertyByTag("key2") == nil`)
+	} else {
+		if pos := DataPositionOf(node); pos.String() != "test.rui:3:3" {
+			t.Errorf("key2.Position() = %s", pos.String())
+		}
+		if pos := DataPositionOf(node.Object()); pos.String() != "test.rui:3:10" {
+			t.Errorf("item.Position() = %s", pos.String())
+		}
+	}
+}
@@ -340,9 +340,9 @@ func (picker *datePickerData) htmlProperties(self View, buffer *strings.Builder)
 	buffer.WriteString(GetDatePickerValue(picker).Format(dateFormat))
 	buffer.WriteByte('"')
 
-	buffer.WriteString(` oninput="editViewInputEvent(this)"`)
+	buffer.WriteString(` data-oninput="editViewInputEvent(this)"`)
 	if picker.getRaw(ClickEvent) == nil {
-		buffer.WriteString(` onclick="stopEventPropagation(this, event)"`)
+		buffer.WriteString(` data-onclick="stopEventPropagation(this, event)"`)
 	}
 
 	dataListHtmlProperties(picker, buffer)
@@ -161,7 +161,7 @@ func (detailsView *detailsViewData) htmlTag() string {
 
 func (detailsView *detailsViewData) htmlProperties(self View, buffer *strings.Builder) {
 	detailsView.viewsContainerData.htmlProperties(self, buffer)
-	buffer.WriteString(` ontoggle="detailsEvent(this)"`)
+	buffer.WriteString(` data-ontoggle="detailsEvent(this)"`)
 	if IsDetailsExpanded(detailsView) {
 		buffer.WriteString(` open`)
 	}
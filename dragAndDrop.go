@@ -408,7 +408,7 @@ func base64DragData(view View) string {
 func dragAndDropHtml(view View, buffer *strings.Builder) {
 
 	if len(getOneArgEventListeners[View, DragAndDropEvent](view, nil, DropEvent)) > 0 {
-		buffer.WriteString(`ondragover="dragOverEvent(this, event)" ondrop="dropEvent(this, event)" `)
+		buffer.WriteString(`data-ondragover="dragOverEvent(this, event)" data-ondrop="dropEvent(this, event)" `)
 		if len(getOneArgEventListeners[View, DragAndDropEvent](view, nil, DragOverEvent)) > 0 {
 			buffer.WriteString(`data-drag-over="1" `)
 		}
@@ -417,23 +417,23 @@ func dragAndDropHtml(view View, buffer *strings.Builder) {
 	if dragData := base64DragData(view); dragData != "" {
 		buffer.WriteString(`draggable="true" data-drag="`)
 		buffer.WriteString(dragData)
-		buffer.WriteString(`" ondragstart="dragStartEvent(this, event)" `)
+		buffer.WriteString(`" data-ondragstart="dragStartEvent(this, event)" `)
 	} else if len(getOneArgEventListeners[View, DragAndDropEvent](view, nil, DragStartEvent)) > 0 {
-		buffer.WriteString(` ondragstart="dragStartEvent(this, event)" `)
+		buffer.WriteString(` data-ondragstart="dragStartEvent(this, event)" `)
 	}
 
 	enterEvent := false
 	switch GetDropEffect(view) {
 	case DropEffectCopy:
-		buffer.WriteString(` data-drop-effect="copy" ondragenter="dragEnterEvent(this, event)"`)
+		buffer.WriteString(` data-drop-effect="copy" data-ondragenter="dragEnterEvent(this, event)"`)
 		enterEvent = true
 
 	case DropEffectMove:
-		buffer.WriteString(` data-drop-effect="move" ondragenter="dragEnterEvent(this, event)"`)
+		buffer.WriteString(` data-drop-effect="move" data-ondragenter="dragEnterEvent(this, event)"`)
 		enterEvent = true
 
 	case DropEffectLink:
-		buffer.WriteString(` data-drop-effect="link" ondragenter="dragEnterEvent(this, event)"`)
+		buffer.WriteString(` data-drop-effect="link" data-ondragenter="dragEnterEvent(this, event)"`)
 		enterEvent = true
 	}
 
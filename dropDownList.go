@@ -244,7 +244,7 @@ func (list *dropDownListData) htmlSubviews(self View, buffer *strings.Builder) {
 
 func (list *dropDownListData) htmlProperties(self View, buffer *strings.Builder) {
 	list.viewData.htmlProperties(self, buffer)
-	buffer.WriteString(` size="1" onchange="dropDownListEvent(this, event)"`)
+	buffer.WriteString(` size="1" data-onchange="dropDownListEvent(this, event)"`)
 }
 
 func (list *dropDownListData) handleCommand(self View, command PropertyName, data DataObject) bool {
@@ -373,7 +373,7 @@ func (edit *editViewData) htmlProperties(self View, buffer *strings.Builder) {
 		buffer.WriteByte('"')
 	}
 
-	buffer.WriteString(` oninput="editViewInputEvent(this)"`)
+	buffer.WriteString(` data-oninput="editViewInputEvent(this)"`)
 	if pattern := GetEditViewPattern(edit); pattern != "" {
 		buffer.WriteString(` pattern="`)
 		buffer.WriteString(convertText(pattern))
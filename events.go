@@ -6,39 +6,39 @@ import (
 )
 
 var eventJsFunc = map[PropertyName]struct{ jsEvent, jsFunc string }{
-	FocusEvent:              {jsEvent: "onfocus", jsFunc: "focusEvent"},
-	LostFocusEvent:          {jsEvent: "onblur", jsFunc: "blurEvent"},
-	KeyDownEvent:            {jsEvent: "onkeydown", jsFunc: "keyDownEvent"},
-	KeyUpEvent:              {jsEvent: "onkeyup", jsFunc: "keyUpEvent"},
-	ClickEvent:              {jsEvent: "onclick", jsFunc: "clickEvent"},
-	DoubleClickEvent:        {jsEvent: "ondblclick", jsFunc: "doubleClickEvent"},
-	MouseDown:               {jsEvent: "onmousedown", jsFunc: "mouseDownEvent"},
-	MouseUp:                 {jsEvent: "onmouseup", jsFunc: "mouseUpEvent"},
-	MouseMove:               {jsEvent: "onmousemove", jsFunc: "mouseMoveEvent"},
-	MouseOut:                {jsEvent: "onmouseout", jsFunc: "mouseOutEvent"},
-	MouseOver:               {jsEvent: "onmouseover", jsFunc: "mouseOverEvent"},
-	ContextMenuEvent:        {jsEvent: "oncontextmenu", jsFunc: "contextMenuEvent"},
-	PointerDown:             {jsEvent: "onpointerdown", jsFunc: "pointerDownEvent"},
-	PointerUp:               {jsEvent: "onpointerup", jsFunc: "pointerUpEvent"},
-	PointerMove:             {jsEvent: "onpointermove", jsFunc: "pointerMoveEvent"},
-	PointerCancel:           {jsEvent: "onpointercancel", jsFunc: "pointerCancelEvent"},
-	PointerOut:              {jsEvent: "onpointerout", jsFunc: "pointerOutEvent"},
-	PointerOver:             {jsEvent: "onpointerover", jsFunc: "pointerOverEvent"},
-	TouchStart:              {jsEvent: "ontouchstart", jsFunc: "touchStartEvent"},
-	TouchEnd:                {jsEvent: "ontouchend", jsFunc: "touchEndEvent"},
-	TouchMove:               {jsEvent: "ontouchmove", jsFunc: "touchMoveEvent"},
-	TouchCancel:             {jsEvent: "ontouchcancel", jsFunc: "touchCancelEvent"},
-	TransitionRunEvent:      {jsEvent: "ontransitionrun", jsFunc: "transitionRunEvent"},
-	TransitionStartEvent:    {jsEvent: "ontransitionstart", jsFunc: "transitionStartEvent"},
-	TransitionEndEvent:      {jsEvent: "ontransitionend", jsFunc: "transitionEndEvent"},
-	TransitionCancelEvent:   {jsEvent: "ontransitioncancel", jsFunc: "transitionCancelEvent"},
-	AnimationStartEvent:     {jsEvent: "onanimationstart", jsFunc: "animationStartEvent"},
-	AnimationEndEvent:       {jsEvent: "onanimationend", jsFunc: "animationEndEvent"},
-	AnimationIterationEvent: {jsEvent: "onanimationiteration", jsFunc: "animationIterationEvent"},
-	AnimationCancelEvent:    {jsEvent: "onanimationcancel", jsFunc: "animationCancelEvent"},
-	DragEndEvent:            {jsEvent: "ondragend", jsFunc: "dragEndEvent"},
-	DragEnterEvent:          {jsEvent: "ondragenter", jsFunc: "dragEnterEvent"},
-	DragLeaveEvent:          {jsEvent: "ondragleave", jsFunc: "dragLeaveEvent"},
+	FocusEvent:              {jsEvent: "data-onfocus", jsFunc: "focusEvent"},
+	LostFocusEvent:          {jsEvent: "data-onblur", jsFunc: "blurEvent"},
+	KeyDownEvent:            {jsEvent: "data-onkeydown", jsFunc: "keyDownEvent"},
+	KeyUpEvent:              {jsEvent: "data-onkeyup", jsFunc: "keyUpEvent"},
+	ClickEvent:              {jsEvent: "data-onclick", jsFunc: "clickEvent"},
+	DoubleClickEvent:        {jsEvent: "data-ondblclick", jsFunc: "doubleClickEvent"},
+	MouseDown:               {jsEvent: "data-onmousedown", jsFunc: "mouseDownEvent"},
+	MouseUp:                 {jsEvent: "data-onmouseup", jsFunc: "mouseUpEvent"},
+	MouseMove:               {jsEvent: "data-onmousemove", jsFunc: "mouseMoveEvent"},
+	MouseOut:                {jsEvent: "data-onmouseout", jsFunc: "mouseOutEvent"},
+	MouseOver:               {jsEvent: "data-onmouseover", jsFunc: "mouseOverEvent"},
+	ContextMenuEvent:        {jsEvent: "data-oncontextmenu", jsFunc: "contextMenuEvent"},
+	PointerDown:             {jsEvent: "data-onpointerdown", jsFunc: "pointerDownEvent"},
+	PointerUp:               {jsEvent: "data-onpointerup", jsFunc: "pointerUpEvent"},
+	PointerMove:             {jsEvent: "data-onpointermove", jsFunc: "pointerMoveEvent"},
+	PointerCancel:           {jsEvent: "data-onpointercancel", jsFunc: "pointerCancelEvent"},
+	PointerOut:              {jsEvent: "data-onpointerout", jsFunc: "pointerOutEvent"},
+	PointerOver:             {jsEvent: "data-onpointerover", jsFunc: "pointerOverEvent"},
+	TouchStart:              {jsEvent: "data-ontouchstart", jsFunc: "touchStartEvent"},
+	TouchEnd:                {jsEvent: "data-ontouchend", jsFunc: "touchEndEvent"},
+	TouchMove:               {jsEvent: "data-ontouchmove", jsFunc: "touchMoveEvent"},
+	TouchCancel:             {jsEvent: "data-ontouchcancel", jsFunc: "touchCancelEvent"},
+	TransitionRunEvent:      {jsEvent: "data-ontransitionrun", jsFunc: "transitionRunEvent"},
+	TransitionStartEvent:    {jsEvent: "data-ontransitionstart", jsFunc: "transitionStartEvent"},
+	TransitionEndEvent:      {jsEvent: "data-ontransitionend", jsFunc: "transitionEndEvent"},
+	TransitionCancelEvent:   {jsEvent: "data-ontransitioncancel", jsFunc: "transitionCancelEvent"},
+	AnimationStartEvent:     {jsEvent: "data-onanimationstart", jsFunc: "animationStartEvent"},
+	AnimationEndEvent:       {jsEvent: "data-onanimationend", jsFunc: "animationEndEvent"},
+	AnimationIterationEvent: {jsEvent: "data-onanimationiteration", jsFunc: "animationIterationEvent"},
+	AnimationCancelEvent:    {jsEvent: "data-onanimationcancel", jsFunc: "animationCancelEvent"},
+	DragEndEvent:            {jsEvent: "data-ondragend", jsFunc: "dragEndEvent"},
+	DragEnterEvent:          {jsEvent: "data-ondragenter", jsFunc: "dragEnterEvent"},
+	DragLeaveEvent:          {jsEvent: "data-ondragleave", jsFunc: "dragLeaveEvent"},
 }
 
 func viewEventsHtml[T any](view View, events []PropertyName, buffer *strings.Builder) {
@@ -268,9 +268,9 @@ func (picker *filePickerData) htmlProperties(self View, buffer *strings.Builder)
 		buffer.WriteString(` multiple`)
 	}
 
-	buffer.WriteString(` oninput="fileSelectedEvent(this)"`)
+	buffer.WriteString(` data-oninput="fileSelectedEvent(this)"`)
 	if picker.getRaw(ClickEvent) == nil {
-		buffer.WriteString(` onclick="stopEventPropagation(this, event)"`)
+		buffer.WriteString(` data-onclick="stopEventPropagation(this, event)"`)
 	}
 }
 
@@ -0,0 +1,88 @@
+//go:build ignore
+
+// This program generates knownProperties.go: the list of the property names which are
+// checked by ValidateViewObject. The names are collected from the exported constants of PropertyName type.
+// Run "go generate" after adding a new property.
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+const outputFile = "knownProperties.go"
+
+func main() {
+	names, err := propertyConstants(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buffer := new(bytes.Buffer)
+	buffer.WriteString("// Code generated by gen_known_properties.go; DO NOT EDIT.\n\n")
+	buffer.WriteString("package rui\n\n")
+	buffer.WriteString("// knownPropertyNames contains the names of all properties supported by the library.\n")
+	buffer.WriteString("// It is used to find unknown properties during the validation of view descriptions.\n")
+	buffer.WriteString("var knownPropertyNames = []PropertyName{\n")
+	for _, name := range names {
+		buffer.WriteString("\t" + name + ",\n")
+	}
+	buffer.WriteString("}\n")
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(outputFile, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// propertyConstants returns the sorted names of the exported constants of PropertyName type
+func propertyConstants(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSet := token.NewFileSet()
+	names := []string{}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") ||
+			name == outputFile || strings.HasPrefix(name, "gen_") {
+			continue
+		}
+
+		source, err := parser.ParseFile(fileSet, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range source.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.CONST {
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.ValueSpec)
+					if ident, ok := spec.Type.(*ast.Ident); ok && ident.Name == "PropertyName" {
+						for _, ident := range spec.Names {
+							if ident.IsExported() {
+								names = append(names, ident.Name)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
@@ -13,13 +13,10 @@ type httpHandler struct {
 }
 
 func (h *httpHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	switch req.Method {
-	case http.MethodGet:
-		path := `/` + strings.TrimPrefix(req.URL.Path, `/`)
-		req.URL.Path = `/` + strings.TrimPrefix(strings.TrimPrefix(path, h.prefix), `/`)
+	path := `/` + strings.TrimPrefix(req.URL.Path, `/`)
+	req.URL.Path = `/` + strings.TrimPrefix(strings.TrimPrefix(path, h.prefix), `/`)
 
-		h.app.ServeHTTP(w, req)
-	}
+	h.app.ServeHTTP(w, req)
 }
 
 // NewHandler is used to embed the rui application in third-party web frameworks (net/http, gin, echo...).
@@ -41,16 +38,8 @@ func (h *httpHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 //		}
 //	})
 func NewHandler(urlPrefix string, createContentFunc func(Session) SessionContent, params AppParams) *httpHandler {
-	app := new(application)
-	app.params = params
-	app.sessions = map[int]sessionInfo{}
-	app.createContentFunc = createContentFunc
-	apps = append(apps, app)
-
-	h := &httpHandler{
-		app:    app,
+	return &httpHandler{
+		app:    newApplication(createContentFunc, params),
 		prefix: `/` + strings.Trim(urlPrefix, `/`),
 	}
-
-	return h
 }
@@ -269,10 +269,10 @@ func (imageView *imageViewData) htmlProperties(self View, buffer *strings.Builde
 		buffer.WriteString(`"`)
 	}
 
-	buffer.WriteString(` onload="imageLoaded(this, event)"`)
+	buffer.WriteString(` data-onload="imageLoaded(this, event)"`)
 
 	if len(getNoArgEventListeners[ImageView](imageView, nil, ErrorEvent)) > 0 {
-		buffer.WriteString(` onerror="imageError(this, event)"`)
+		buffer.WriteString(` data-onerror="imageError(this, event)"`)
 	}
 }
 
@@ -1,7 +1,5 @@
 package rui
 
 func init() {
-	if theme, ok := CreateThemeFromText(defaultThemeText); ok {
-		defaultTheme = theme
-	}
+	resources.defaultTheme = builtinTheme()
 }
@@ -0,0 +1,376 @@
+package rui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ViewTreeNode describes the view and its children (see InspectViewTree)
+type ViewTreeNode struct {
+	// View is the described view
+	View View
+
+	// Tag is the view type name (the Tag() method result)
+	Tag string
+
+	// ID is the view ID (the "id" property value)
+	ID string
+
+	// HTMLID is the id of the HTML element of the view
+	HTMLID string
+
+	// Frame is the location and size of the view in pixels
+	Frame Frame
+
+	// Properties contains the text representations of all properties set for the view,
+	// the child views (the "content" property) are excluded
+	Properties map[PropertyName]string
+
+	// Children contains the nodes of the child views
+	Children []*ViewTreeNode
+}
+
+// InspectViewTree returns the description of the view and all its children.
+// Returns nil if the view is nil
+func InspectViewTree(view View) *ViewTreeNode {
+	if view == nil {
+		return nil
+	}
+
+	node := &ViewTreeNode{
+		View:       view,
+		Tag:        view.Tag(),
+		ID:         view.ID(),
+		HTMLID:     view.htmlID(),
+		Frame:      view.Frame(),
+		Properties: map[PropertyName]string{},
+		Children:   []*ViewTreeNode{},
+	}
+
+	for _, tag := range view.AllTags() {
+		if tag != Content {
+			if value := view.Get(tag); value != nil {
+				text := propertyValueToString(tag, value, "")
+				if text == "" {
+					text = fmt.Sprintf("%T", value)
+				}
+				node.Properties[tag] = text
+			}
+		}
+	}
+
+	if container, ok := view.(ParentView); ok {
+		for _, child := range container.Views() {
+			if child != nil {
+				node.Children = append(node.Children, InspectViewTree(child))
+			}
+		}
+	}
+
+	return node
+}
+
+// Title returns the short description of the node: the tag, the ID and the HTML id of the view
+func (node *ViewTreeNode) Title() string {
+	text := node.Tag
+	if node.ID != "" {
+		text += " #" + node.ID
+	}
+	return text + " (" + node.HTMLID + ")"
+}
+
+// PropertiesText returns the frame and the properties of the view as multiline text
+func (node *ViewTreeNode) PropertiesText() string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	node.writeProperties(buffer, "")
+	return buffer.String()
+}
+
+func (node *ViewTreeNode) writeProperties(buffer *strings.Builder, indent string) {
+	fmt.Fprintf(buffer, "%sframe = %gpx, %gpx, %gpx × %gpx\n", indent,
+		node.Frame.Left, node.Frame.Top, node.Frame.Width, node.Frame.Height)
+
+	tags := make([]PropertyName, 0, len(node.Properties))
+	for tag := range node.Properties {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+
+	for _, tag := range tags {
+		buffer.WriteString(indent)
+		buffer.WriteString(string(tag))
+		buffer.WriteString(" = ")
+		buffer.WriteString(strings.ReplaceAll(node.Properties[tag], "\n", "\n"+indent))
+		buffer.WriteRune('\n')
+	}
+}
+
+// String returns the hierarchical dump of the view tree
+func (node *ViewTreeNode) String() string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+	node.writeString(buffer, "")
+	return buffer.String()
+}
+
+func (node *ViewTreeNode) writeString(buffer *strings.Builder, indent string) {
+	buffer.WriteString(indent)
+	buffer.WriteString(node.Title())
+	buffer.WriteRune('\n')
+
+	indent += "\t"
+	node.writeProperties(buffer, indent+"  ")
+	for _, child := range node.Children {
+		child.writeString(buffer, indent)
+	}
+}
+
+type inspectorData struct {
+	session  Session
+	root     *ViewTreeNode
+	selected *ViewTreeNode
+	rows     map[*ViewTreeNode]View
+	details  EditView
+	popup    Popup
+	picking  bool
+	onClose  func()
+}
+
+// ShowInspector shows the popup with the view tree of the root view of the session.
+// Select a view in the tree to see its properties and to highlight it on the page.
+// The "Pick" button hides the popup until a view is clicked on the page, then the popup is shown
+// again with the clicked view selected.
+//
+// The inspector is a development tool, do not use it in production.
+func ShowInspector(session Session) Popup {
+	inspector := &inspectorData{session: session}
+	inspector.show(session.RootView())
+	return inspector.popup
+}
+
+// EnableInspector registers the hotkey which shows/hides the inspector (see ShowInspector).
+//
+// Invoke DisableInspector with the same key to remove the hotkey.
+func EnableInspector(session Session, keyCode KeyCode, controlKeys ControlKeyMask) {
+	var inspector *inspectorData
+	session.SetHotKey(keyCode, controlKeys, func(session Session) {
+		if inspector != nil {
+			inspector.close()
+			return
+		}
+
+		inspector = &inspectorData{
+			session: session,
+			onClose: func() {
+				inspector = nil
+			},
+		}
+		inspector.show(session.RootView())
+	})
+}
+
+// DisableInspector removes the hotkey registered by EnableInspector
+func DisableInspector(session Session, keyCode KeyCode, controlKeys ControlKeyMask) {
+	session.SetHotKey(keyCode, controlKeys, nil)
+}
+
+func (inspector *inspectorData) show(selected View) {
+	session := inspector.session
+
+	// the tree is built from the top ancestor of the view, so the views of other popups can be inspected too
+	root := selected
+	if root != nil {
+		for parent := root.Parent(); parent != nil; parent = parent.Parent() {
+			root = parent
+		}
+	}
+
+	inspector.root = InspectViewTree(root)
+	inspector.selected = nil
+	inspector.rows = map[*ViewTreeNode]View{}
+
+	tree := []View{}
+	var addNode func(node *ViewTreeNode, level int)
+	addNode = func(node *ViewTreeNode, level int) {
+		row := NewTextView(session, Params{
+			Text:         node.Title(),
+			NotTranslate: true,
+			Padding:      Bounds{Top: Px(2), Right: Px(8), Bottom: Px(2), Left: Px(float64(8 + 16*level))},
+			Cursor:       "pointer",
+			WhiteSpace:   WhiteSpaceNowrap,
+			ClickEvent: func() {
+				inspector.selectNode(node)
+			},
+		})
+		inspector.rows[node] = row
+		tree = append(tree, row)
+		for _, child := range node.Children {
+			addNode(child, level+1)
+		}
+	}
+	if inspector.root != nil {
+		addNode(inspector.root, 0)
+	}
+
+	inspector.details = NewEditView(session, Params{
+		EditViewType: MultiLineText,
+		ReadOnly:     true,
+		Spellcheck:   false,
+		EditWrap:     false,
+		Width:        Em(30),
+		Height:       Percent(100),
+	})
+
+	content := NewGridLayout(session, Params{
+		ID:            "ruiInspector",
+		CellWidth:     []SizeUnit{Fr(1), AutoSize()},
+		Height:        Em(30),
+		GridColumnGap: Px(8),
+		Padding:       Px(8),
+		Content: []View{
+			NewListLayout(session, Params{
+				Orientation: TopDownOrientation,
+				MinWidth:    Em(20),
+				Height:      Percent(100),
+				Overflow:    OverflowAuto,
+				Content:     tree,
+			}),
+			inspector.details,
+		},
+	})
+
+	inspector.popup = NewPopup(content, Params{
+		Title:        "Inspector",
+		CloseButton:  true,
+		OutsideClose: false,
+		Buttons: []PopupButton{
+			{
+				Title:   "Pick",
+				OnClick: inspector.pick,
+			},
+			{
+				Title: "Refresh",
+				OnClick: func(popup Popup) {
+					inspector.refresh()
+				},
+			},
+			{
+				Title: "Close",
+				Type:  CancelButton,
+				OnClick: func(popup Popup) {
+					popup.Dismiss()
+				},
+			},
+		},
+		DismissEvent: func() {
+			inspector.popup = nil
+			session.callFunc("inspectorHighlight", "")
+			if !inspector.picking && inspector.onClose != nil {
+				inspector.onClose()
+			}
+		},
+	})
+	inspector.popup.Show()
+
+	if selected != nil {
+		inspector.selectNode(inspector.root.find(selected))
+	}
+}
+
+// find returns the node of the view or nil
+func (node *ViewTreeNode) find(view View) *ViewTreeNode {
+	if node == nil || node.HTMLID == view.htmlID() {
+		return node
+	}
+	for _, child := range node.Children {
+		if result := child.find(view); result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
+func (inspector *inspectorData) selectNode(node *ViewTreeNode) {
+	if node == nil {
+		return
+	}
+
+	if row, ok := inspector.rows[inspector.selected]; ok {
+		row.Remove(BackgroundColor)
+		row.Remove(TextColor)
+	}
+
+	inspector.selected = node
+	if row, ok := inspector.rows[node]; ok {
+		row.Set(BackgroundColor, "@ruiHighlightColor")
+		row.Set(TextColor, "@ruiHighlightTextColor")
+		inspector.session.callFunc("scrollIntoViewIfNeeded", row.htmlID())
+	}
+
+	inspector.details.Set(Text, node.PropertiesText())
+	inspector.session.callFunc("inspectorHighlight", node.HTMLID)
+}
+
+func (inspector *inspectorData) refresh() {
+	var selected View
+	if inspector.selected != nil {
+		selected = inspector.selected.View
+	} else {
+		selected = inspector.session.RootView()
+	}
+
+	inspector.picking = true
+	inspector.popup.DismissWithoutAnimation()
+	inspector.picking = false
+	inspector.show(selected)
+}
+
+func (inspector *inspectorData) pick(popup Popup) {
+	session := inspector.session
+	inspector.picking = true
+	popup.DismissWithoutAnimation()
+
+	session.setInspectorPick(func(view View) {
+		inspector.picking = false
+		inspector.show(view)
+	})
+	session.callFunc("inspectorPick", true)
+}
+
+func (inspector *inspectorData) close() {
+	if inspector.popup != nil {
+		inspector.popup.Dismiss()
+		return
+	}
+
+	if inspector.picking {
+		inspector.picking = false
+		inspector.session.setInspectorPick(nil)
+		inspector.session.callFunc("inspectorPick", false)
+		if inspector.onClose != nil {
+			inspector.onClose()
+		}
+	}
+}
+
+func (session *sessionData) setInspectorPick(fn func(View)) {
+	session.inspectorPick = fn
+}
+
+func (session *sessionData) handleInspectorPick(data DataObject) {
+	fn := session.inspectorPick
+	session.inspectorPick = nil
+	if fn == nil {
+		return
+	}
+
+	if id, ok := data.PropertyValue("id"); ok {
+		if view := session.viewByHTMLID(id); view != nil {
+			fn(view)
+			return
+		}
+	}
+	fn(session.RootView())
+}
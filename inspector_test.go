@@ -0,0 +1,39 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInspectViewTree(t *testing.T) {
+	session := newSession(nil, 0, "", nil).(*sessionData)
+	root := NewListLayout(session, Params{
+		ID: "root",
+		Content: []View{
+			NewTextView(session, Params{ID: "label", Text: "Hello"}),
+			NewButton(session, Params{ID: "ok"}),
+		},
+	})
+
+	node := InspectViewTree(root)
+	if node == nil || node.ID != "root" || len(node.Children) != 2 {
+		t.Fatal("invalid view tree")
+	}
+
+	label := node.Children[0]
+	if label.Tag != "TextView" || label.HTMLID != root.Views()[0].htmlID() {
+		t.Errorf(`invalid node of "label": %s`, label.Title())
+	}
+	if text := label.Properties[Text]; text != `"Hello"` {
+		t.Errorf(`text = %s, expected "Hello"`, text)
+	}
+	if _, ok := node.Properties[Content]; ok {
+		t.Error(`"content" property is not excluded`)
+	}
+	if node.find(root.Views()[1]) != node.Children[1] {
+		t.Error(`"ok" view is not found`)
+	}
+	if text := node.String(); !strings.Contains(text, "\tButton #ok (") {
+		t.Errorf("invalid dump:\n%s", text)
+	}
+}
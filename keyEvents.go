@@ -437,11 +437,11 @@ func keyEventsHtml(view View, buffer *strings.Builder) {
 	if len(getOneArgEventListeners[View, KeyEvent](view, nil, KeyDownEvent)) > 0 ||
 		(view.Focusable() && len(getOneArgEventListeners[View, MouseEvent](view, nil, ClickEvent)) > 0) {
 
-		buffer.WriteString(`onkeydown="keyDownEvent(this, event)" `)
+		buffer.WriteString(`data-onkeydown="keyDownEvent(this, event)" `)
 	}
 
 	if len(getOneArgEventListeners[View, KeyEvent](view, nil, KeyUpEvent)) > 0 {
-		buffer.WriteString(`onkeyup="keyUpEvent(this, event)" `)
+		buffer.WriteString(`data-onkeyup="keyUpEvent(this, event)" `)
 	}
 }
 
@@ -679,7 +679,9 @@ func (listView *listViewData) checkboxSubviews(adapter ListAdapter, buffer *stri
 			buffer.WriteString(listViewCurrentInactiveStyle(listView))
 		}
 
-		buffer.WriteString(`" onclick="listItemClickEvent(this, event)" data-left="0" data-top="0" data-width="0" data-height="0" style="display: grid; justify-items: stretch; align-items: stretch;`)
+		buffer.WriteString(`" `)
+		listItemAriaHtml(buffer, i == current, slices.Contains(checkedItems, i))
+		buffer.WriteString(`data-onclick="listItemClickEvent(this, event)" data-left="0" data-top="0" data-width="0" data-height="0" style="display: grid; justify-items: stretch; align-items: stretch;`)
 		listView.itemSize(buffer)
 		if enabledItems != nil && !enabledItems.IsListItemEnabled(i) {
 			buffer.WriteString(`" inert>`)
@@ -724,7 +726,7 @@ func (listView *listViewData) noneCheckboxSubviews(adapter ListAdapter, buffer *
 	listView.itemAlign(itemStyleBuilder)
 	listView.itemSize(itemStyleBuilder)
 
-	itemStyleBuilder.WriteString(`" onclick="listItemClickEvent(this, event)"`)
+	itemStyleBuilder.WriteString(`" data-onclick="listItemClickEvent(this, event)"`)
 	itemStyle := itemStyleBuilder.String()
 
 	current := GetCurrent(listView)
@@ -741,7 +743,12 @@ func (listView *listViewData) noneCheckboxSubviews(adapter ListAdapter, buffer *
 			buffer.WriteRune(' ')
 			buffer.WriteString(listViewCurrentInactiveStyle(listView))
 		}
-		buffer.WriteString(`" `)
+		buffer.WriteString(`" role="option" aria-selected="`)
+		if i == current {
+			buffer.WriteString(`true" `)
+		} else {
+			buffer.WriteString(`false" `)
+		}
 		buffer.WriteString(itemStyle)
 		if enabledItems != nil && !enabledItems.IsListItemEnabled(i) {
 			buffer.WriteString(` inert`)
@@ -759,6 +766,19 @@ func (listView *listViewData) noneCheckboxSubviews(adapter ListAdapter, buffer *
 	}
 }
 
+func listItemAriaHtml(buffer *strings.Builder, selected, checked bool) {
+	if selected {
+		buffer.WriteString(`role="option" aria-selected="true" `)
+	} else {
+		buffer.WriteString(`role="option" aria-selected="false" `)
+	}
+	if checked {
+		buffer.WriteString(`aria-checked="true" `)
+	} else {
+		buffer.WriteString(`aria-checked="false" `)
+	}
+}
+
 func (listView *listViewData) updateCheckboxItem(index int, checked bool) {
 
 	checkbox := GetListViewCheckbox(listView)
@@ -787,24 +807,39 @@ func (listView *listViewData) updateCheckboxItem(index int, checked bool) {
 		}
 	}
 	buffer.WriteString(`</div></div>`)
-	session.updateInnerHTML(listView.htmlID()+"-"+strconv.Itoa(index), buffer.String())
+	itemID := listView.htmlID() + "-" + strconv.Itoa(index)
+	session.updateInnerHTML(itemID, buffer.String())
+	if checked {
+		session.updateProperty(itemID, "aria-checked", "true")
+	} else {
+		session.updateProperty(itemID, "aria-checked", "false")
+	}
+}
+
+func (listView *listViewData) htmlRole() string {
+	return "listbox"
 }
 
 func (listView *listViewData) htmlProperties(self View, buffer *strings.Builder) {
 	listView.viewData.htmlProperties(self, buffer)
-	buffer.WriteString(`onfocus="listViewFocusEvent(this, event)" onblur="listViewBlurEvent(this, event)"`)
-	buffer.WriteString(` onkeydown="listViewKeyDownEvent(this, event)" data-focusitemstyle="`)
+	buffer.WriteString(`data-onfocus="listViewFocusEvent(this, event)" data-onblur="listViewBlurEvent(this, event)"`)
+	buffer.WriteString(` data-onkeydown="listViewKeyDownEvent(this, event)" data-focusitemstyle="`)
 	buffer.WriteString(listViewCurrentStyle(listView))
 	buffer.WriteString(`" data-bluritemstyle="`)
 	buffer.WriteString(listViewCurrentInactiveStyle(listView))
 	buffer.WriteString(`"`)
 
+	if GetListViewCheckbox(listView) == MultipleCheckbox {
+		buffer.WriteString(` aria-multiselectable="true"`)
+	}
+
 	if adapter := listView.getAdapter(); adapter != nil {
 		if current := GetCurrent(listView); current >= 0 && current < adapter.ListSize() {
+			itemID := listView.htmlID() + "-" + strconv.Itoa(current)
 			buffer.WriteString(` data-current="`)
-			buffer.WriteString(listView.htmlID())
-			buffer.WriteRune('-')
-			buffer.WriteString(strconv.Itoa(current))
+			buffer.WriteString(itemID)
+			buffer.WriteString(`" aria-activedescendant="`)
+			buffer.WriteString(itemID)
 			buffer.WriteRune('"')
 		}
 	}
@@ -0,0 +1,838 @@
+package rui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Constants of date and time format lengths used by LocaleFormatter
+const (
+	// FormatShort is the short date/time format, for example "1/2/06" or "3:04 PM"
+	FormatShort = 0
+
+	// FormatMedium is the medium date/time format, for example "Jan 2, 2006" or "3:04:05 PM"
+	FormatMedium = 1
+
+	// FormatLong is the long date format, for example "January 2, 2006"
+	FormatLong = 2
+
+	// FormatFull is the full date format, for example "Monday, January 2, 2006"
+	FormatFull = 3
+)
+
+// LocaleData describes the formatting rules of a locale used by LocaleFormatter.
+//
+// Date and time patterns use the following fields (as in CLDR): y, yy, yyyy - year; M, MM - month number;
+// MMM - short month name; MMMM - month name; d, dd - day; E, EEE - short weekday name; EEEE - weekday name;
+// H, HH - hour (0-23); h, hh - hour (1-12); m, mm - minutes; s, ss - seconds; a - day period (AM/PM).
+// Text in apostrophes is written as is.
+type LocaleData struct {
+	// DecimalSeparator is the separator of the fractional part of a number
+	DecimalSeparator string
+
+	// GroupSeparator is the separator of the thousands groups
+	GroupSeparator string
+
+	// PercentPattern is the percent format: "#" is replaced by the number
+	PercentPattern string
+
+	// CurrencyPattern is the currency format: "#" is replaced by the number, "¤" by the currency symbol
+	CurrencyPattern string
+
+	// DatePatterns are the date patterns for FormatShort, FormatMedium, FormatLong and FormatFull
+	DatePatterns [4]string
+
+	// TimePatterns are the time patterns for FormatShort and FormatMedium
+	TimePatterns [2]string
+
+	// DateTimePattern combines a date and a time: "{date}" and "{time}" are replaced by them
+	DateTimePattern string
+
+	// Months are the month names (used in dates, so the genitive case for some languages)
+	Months [12]string
+
+	// ShortMonths are the abbreviated month names
+	ShortMonths [12]string
+
+	// Weekdays are the weekday names starting from Sunday
+	Weekdays [7]string
+
+	// ShortWeekdays are the abbreviated weekday names starting from Sunday
+	ShortWeekdays [7]string
+
+	// DayPeriods are the names of AM and PM
+	DayPeriods [2]string
+
+	// Units are the messages (see FormatMessage) of time units with the {n} parameter used by the relative time format.
+	// Keys: "second", "minute", "hour", "day", "week", "month", "year"
+	Units map[string]string
+
+	// DurationUnits are the messages of time units used by the duration format. If not set, Units are used
+	DurationUnits map[string]string
+
+	// RelativeFuture is the pattern of a future time: "{0}" is replaced by the unit text, e.g. "in {0}"
+	RelativeFuture string
+
+	// RelativePast is the pattern of a past time: "{0}" is replaced by the unit text, e.g. "{0} ago"
+	RelativePast string
+
+	// Now is the text of a relative time less than 1 second
+	Now string
+}
+
+// LocaleFormatter formats numbers, percentages, currencies, dates, relative times and durations
+// according to the rules of a locale. The formatter of a session is returned by the Session.Formatter method.
+type LocaleFormatter interface {
+	// Language returns the language of the locale
+	Language() string
+
+	// Number returns the number with the thousands separators. If decimals < 0 then all significant digits
+	// of the fractional part are written, otherwise the number is rounded to the specified digits
+	Number(value float64, decimals int) string
+
+	// Percent returns the value as a percentage (0.25 is "25%")
+	Percent(value float64, decimals int) string
+
+	// Currency returns the currency amount, "currency" is the ISO 4217 code of a currency (e.g. "USD" or "EUR")
+	Currency(value float64, currency string) string
+
+	// Date returns the date. Valid "length" values: FormatShort, FormatMedium, FormatLong, FormatFull
+	Date(t time.Time, length int) string
+
+	// Time returns the time. Valid "length" values: FormatShort, FormatMedium
+	Time(t time.Time, length int) string
+
+	// DateTime returns the date and the time
+	DateTime(t time.Time, length int) string
+
+	// RelativeTime returns the time relative to "now", for example "in 3 days" or "5 minutes ago"
+	RelativeTime(t time.Time, now time.Time) string
+
+	// Duration returns the duration, for example "1 hour 30 minutes"
+	Duration(d time.Duration) string
+
+	// Format returns the value formatted according to the "format" description:
+	//   - "number" or "number:N" - a number with N fractional digits;
+	//   - "integer" - a rounded number;
+	//   - "percent" or "percent:N" - a percentage with N fractional digits;
+	//   - "currency:CODE" - a currency amount, e.g. "currency:EUR";
+	//   - "date", "time", "datetime", optionally with the length ":short", ":medium", ":long" or ":full";
+	//   - "relative" - the time relative to the current moment;
+	//   - "duration" - the duration (time.Duration, or a number of seconds);
+	//   - a pattern of date/time fields (see LocaleData), e.g. "dd.MM.yyyy HH:mm";
+	//   - a message (see FormatMessage) with the "value" parameter, e.g. "{value, number} kg".
+	Format(value any, format string) string
+}
+
+type localeFormatter struct {
+	lang string
+	data *LocaleData
+}
+
+var localeData = struct {
+	sync.RWMutex
+	locales map[string]*LocaleData
+}{locales: map[string]*LocaleData{
+	"en": {
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		PercentPattern:   "#%",
+		CurrencyPattern:  "¤#",
+		DatePatterns:     [4]string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+		TimePatterns:     [2]string{"h:mm a", "h:mm:ss a"},
+		DateTimePattern:  "{date}, {time}",
+		Months:           [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		ShortMonths:      [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:         [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays:    [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		DayPeriods:       [2]string{"AM", "PM"},
+		Units: map[string]string{
+			"second": "{n, plural, one {# second} other {# seconds}}",
+			"minute": "{n, plural, one {# minute} other {# minutes}}",
+			"hour":   "{n, plural, one {# hour} other {# hours}}",
+			"day":    "{n, plural, one {# day} other {# days}}",
+			"week":   "{n, plural, one {# week} other {# weeks}}",
+			"month":  "{n, plural, one {# month} other {# months}}",
+			"year":   "{n, plural, one {# year} other {# years}}",
+		},
+		RelativeFuture: "in {0}",
+		RelativePast:   "{0} ago",
+		Now:            "now",
+	},
+	"de": {
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		PercentPattern:   "#\u00a0%",
+		CurrencyPattern:  "#\u00a0¤",
+		DatePatterns:     [4]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		TimePatterns:     [2]string{"HH:mm", "HH:mm:ss"},
+		DateTimePattern:  "{date}, {time}",
+		Months:           [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths:      [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		Weekdays:         [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdays:    [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		DayPeriods:       [2]string{"AM", "PM"},
+		Units: map[string]string{
+			"second": "{n, plural, one {# Sekunde} other {# Sekunden}}",
+			"minute": "{n, plural, one {# Minute} other {# Minuten}}",
+			"hour":   "{n, plural, one {# Stunde} other {# Stunden}}",
+			"day":    "{n, plural, one {# Tag} other {# Tagen}}",
+			"week":   "{n, plural, one {# Woche} other {# Wochen}}",
+			"month":  "{n, plural, one {# Monat} other {# Monaten}}",
+			"year":   "{n, plural, one {# Jahr} other {# Jahren}}",
+		},
+		DurationUnits: map[string]string{
+			"second": "{n, plural, one {# Sekunde} other {# Sekunden}}",
+			"minute": "{n, plural, one {# Minute} other {# Minuten}}",
+			"hour":   "{n, plural, one {# Stunde} other {# Stunden}}",
+			"day":    "{n, plural, one {# Tag} other {# Tage}}",
+			"week":   "{n, plural, one {# Woche} other {# Wochen}}",
+			"month":  "{n, plural, one {# Monat} other {# Monate}}",
+			"year":   "{n, plural, one {# Jahr} other {# Jahre}}",
+		},
+		RelativeFuture: "in {0}",
+		RelativePast:   "vor {0}",
+		Now:            "jetzt",
+	},
+	"fr": {
+		DecimalSeparator: ",",
+		GroupSeparator:   " ",
+		PercentPattern:   "#\u00a0%",
+		CurrencyPattern:  "#\u00a0¤",
+		DatePatterns:     [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		TimePatterns:     [2]string{"HH:mm", "HH:mm:ss"},
+		DateTimePattern:  "{date} {time}",
+		Months:           [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths:      [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		Weekdays:         [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortWeekdays:    [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		DayPeriods:       [2]string{"AM", "PM"},
+		Units: map[string]string{
+			"second": "{n, plural, one {# seconde} other {# secondes}}",
+			"minute": "{n, plural, one {# minute} other {# minutes}}",
+			"hour":   "{n, plural, one {# heure} other {# heures}}",
+			"day":    "{n, plural, one {# jour} other {# jours}}",
+			"week":   "{n, plural, one {# semaine} other {# semaines}}",
+			"month":  "{n, plural, one {# mois} other {# mois}}",
+			"year":   "{n, plural, one {# an} other {# ans}}",
+		},
+		RelativeFuture: "dans {0}",
+		RelativePast:   "il y a {0}",
+		Now:            "maintenant",
+	},
+	"es": {
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		PercentPattern:   "#\u00a0%",
+		CurrencyPattern:  "#\u00a0¤",
+		DatePatterns:     [4]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		TimePatterns:     [2]string{"H:mm", "H:mm:ss"},
+		DateTimePattern:  "{date}, {time}",
+		Months:           [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths:      [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Weekdays:         [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortWeekdays:    [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		DayPeriods:       [2]string{"a.\u00a0m.", "p.\u00a0m."},
+		Units: map[string]string{
+			"second": "{n, plural, one {# segundo} other {# segundos}}",
+			"minute": "{n, plural, one {# minuto} other {# minutos}}",
+			"hour":   "{n, plural, one {# hora} other {# horas}}",
+			"day":    "{n, plural, one {# día} other {# días}}",
+			"week":   "{n, plural, one {# semana} other {# semanas}}",
+			"month":  "{n, plural, one {# mes} other {# meses}}",
+			"year":   "{n, plural, one {# año} other {# años}}",
+		},
+		RelativeFuture: "dentro de {0}",
+		RelativePast:   "hace {0}",
+		Now:            "ahora",
+	},
+	"it": {
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		PercentPattern:   "#%",
+		CurrencyPattern:  "#\u00a0¤",
+		DatePatterns:     [4]string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		TimePatterns:     [2]string{"HH:mm", "HH:mm:ss"},
+		DateTimePattern:  "{date}, {time}",
+		Months:           [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		ShortMonths:      [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Weekdays:         [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		ShortWeekdays:    [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		DayPeriods:       [2]string{"AM", "PM"},
+		Units: map[string]string{
+			"second": "{n, plural, one {# secondo} other {# secondi}}",
+			"minute": "{n, plural, one {# minuto} other {# minuti}}",
+			"hour":   "{n, plural, one {# ora} other {# ore}}",
+			"day":    "{n, plural, one {# giorno} other {# giorni}}",
+			"week":   "{n, plural, one {# settimana} other {# settimane}}",
+			"month":  "{n, plural, one {# mese} other {# mesi}}",
+			"year":   "{n, plural, one {# anno} other {# anni}}",
+		},
+		RelativeFuture: "tra {0}",
+		RelativePast:   "{0} fa",
+		Now:            "ora",
+	},
+	"pt": {
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		PercentPattern:   "#%",
+		CurrencyPattern:  "¤\u00a0#",
+		DatePatterns:     [4]string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		TimePatterns:     [2]string{"HH:mm", "HH:mm:ss"},
+		DateTimePattern:  "{date} {time}",
+		Months:           [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		ShortMonths:      [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		Weekdays:         [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		ShortWeekdays:    [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		DayPeriods:       [2]string{"AM", "PM"},
+		Units: map[string]string{
+			"second": "{n, plural, one {# segundo} other {# segundos}}",
+			"minute": "{n, plural, one {# minuto} other {# minutos}}",
+			"hour":   "{n, plural, one {# hora} other {# horas}}",
+			"day":    "{n, plural, one {# dia} other {# dias}}",
+			"week":   "{n, plural, one {# semana} other {# semanas}}",
+			"month":  "{n, plural, one {# mês} other {# meses}}",
+			"year":   "{n, plural, one {# ano} other {# anos}}",
+		},
+		RelativeFuture: "em {0}",
+		RelativePast:   "há {0}",
+		Now:            "agora",
+	},
+	"ru": {
+		DecimalSeparator: ",",
+		GroupSeparator:   "\u00a0",
+		PercentPattern:   "#\u00a0%",
+		CurrencyPattern:  "#\u00a0¤",
+		DatePatterns:     [4]string{"dd.MM.y", "d MMM y 'г'.", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		TimePatterns:     [2]string{"HH:mm", "HH:mm:ss"},
+		DateTimePattern:  "{date}, {time}",
+		Months:           [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		ShortMonths:      [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		Weekdays:         [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		ShortWeekdays:    [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		DayPeriods:       [2]string{"AM", "PM"},
+		Units: map[string]string{
+			"second": "{n, plural, one {# секунду} few {# секунды} many {# секунд} other {# секунды}}",
+			"minute": "{n, plural, one {# минуту} few {# минуты} many {# минут} other {# минуты}}",
+			"hour":   "{n, plural, one {# час} few {# часа} many {# часов} other {# часа}}",
+			"day":    "{n, plural, one {# день} few {# дня} many {# дней} other {# дня}}",
+			"week":   "{n, plural, one {# неделю} few {# недели} many {# недель} other {# недели}}",
+			"month":  "{n, plural, one {# месяц} few {# месяца} many {# месяцев} other {# месяца}}",
+			"year":   "{n, plural, one {# год} few {# года} many {# лет} other {# года}}",
+		},
+		DurationUnits: map[string]string{
+			"second": "{n, plural, one {# секунда} few {# секунды} many {# секунд} other {# секунды}}",
+			"minute": "{n, plural, one {# минута} few {# минуты} many {# минут} other {# минуты}}",
+			"hour":   "{n, plural, one {# час} few {# часа} many {# часов} other {# часа}}",
+			"day":    "{n, plural, one {# день} few {# дня} many {# дней} other {# дня}}",
+			"week":   "{n, plural, one {# неделя} few {# недели} many {# недель} other {# недели}}",
+			"month":  "{n, plural, one {# месяц} few {# месяца} many {# месяцев} other {# месяца}}",
+			"year":   "{n, plural, one {# год} few {# года} many {# лет} other {# года}}",
+		},
+		RelativeFuture: "через {0}",
+		RelativePast:   "{0} назад",
+		Now:            "сейчас",
+	},
+	"ja": {
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		PercentPattern:   "#%",
+		CurrencyPattern:  "¤#",
+		DatePatterns:     [4]string{"y/MM/dd", "y/MM/dd", "y年M月d日", "y年M月d日EEEE"},
+		TimePatterns:     [2]string{"H:mm", "H:mm:ss"},
+		DateTimePattern:  "{date} {time}",
+		Months:           [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		ShortMonths:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:         [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		ShortWeekdays:    [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		DayPeriods:       [2]string{"午前", "午後"},
+		Units: map[string]string{
+			"second": "{n}秒",
+			"minute": "{n}分",
+			"hour":   "{n}時間",
+			"day":    "{n}日",
+			"week":   "{n}週間",
+			"month":  "{n}か月",
+			"year":   "{n}年",
+		},
+		RelativeFuture: "{0}後",
+		RelativePast:   "{0}前",
+		Now:            "今",
+	},
+	"zh": {
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		PercentPattern:   "#%",
+		CurrencyPattern:  "¤#",
+		DatePatterns:     [4]string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日EEEE"},
+		TimePatterns:     [2]string{"HH:mm", "HH:mm:ss"},
+		DateTimePattern:  "{date} {time}",
+		Months:           [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		ShortMonths:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays:         [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		ShortWeekdays:    [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		DayPeriods:       [2]string{"上午", "下午"},
+		Units: map[string]string{
+			"second": "{n}秒钟",
+			"minute": "{n}分钟",
+			"hour":   "{n}小时",
+			"day":    "{n}天",
+			"week":   "{n}周",
+			"month":  "{n}个月",
+			"year":   "{n}年",
+		},
+		RelativeFuture: "{0}后",
+		RelativePast:   "{0}前",
+		Now:            "现在",
+	},
+}}
+
+func init() {
+	enGB := *localeData.locales["en"]
+	enGB.DatePatterns = [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"}
+	enGB.TimePatterns = [2]string{"HH:mm", "HH:mm:ss"}
+	localeData.locales["en-GB"] = &enGB
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"RUB": "₽",
+	"INR": "₹",
+	"KRW": "₩",
+	"UAH": "₴",
+	"BRL": "R$",
+}
+
+var currencyDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+}
+
+// SetLocaleData sets the formatting rules of the locale (e.g. "de", "en-GB").
+// The built-in locales: en, en-GB, de, fr, es, it, pt, ru, ja, zh.
+func SetLocaleData(lang string, data LocaleData) {
+	localeData.Lock()
+	defer localeData.Unlock()
+	localeData.locales[lang] = &data
+}
+
+// NewLocaleFormatter returns the formatter for the language. The formatting rules are searched
+// in the language chain (see SetLanguageFallback), "en" is used if they are not found.
+func NewLocaleFormatter(lang string) LocaleFormatter {
+	return newLocaleFormatter([]string{lang})
+}
+
+func newLocaleFormatter(languages []string) LocaleFormatter {
+	chain := languageChain(languages...)
+
+	localeData.RLock()
+	defer localeData.RUnlock()
+
+	for _, lang := range chain {
+		if data, ok := localeData.locales[lang]; ok {
+			return &localeFormatter{lang: lang, data: data}
+		}
+	}
+	return &localeFormatter{lang: "en", data: localeData.locales["en"]}
+}
+
+func (session *sessionData) Formatter() LocaleFormatter {
+	return newLocaleFormatter(session.stringLanguages())
+}
+
+func (formatter *localeFormatter) Language() string {
+	return formatter.lang
+}
+
+func (formatter *localeFormatter) Number(value float64, decimals int) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	text := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	intPart, fracPart, _ := strings.Cut(text, ".")
+
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	if value < 0 && strings.Trim(text, "0.") != "" {
+		buffer.WriteRune('-')
+	}
+
+	for i, ch := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			buffer.WriteString(formatter.data.GroupSeparator)
+		}
+		buffer.WriteRune(ch)
+	}
+
+	if fracPart != "" {
+		buffer.WriteString(formatter.data.DecimalSeparator)
+		buffer.WriteString(fracPart)
+	}
+
+	return buffer.String()
+}
+
+func (formatter *localeFormatter) Percent(value float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	return strings.Replace(formatter.data.PercentPattern, "#", formatter.Number(value*100, decimals), 1)
+}
+
+func (formatter *localeFormatter) Currency(value float64, currency string) string {
+	currency = strings.ToUpper(currency)
+	decimals, ok := currencyDecimals[currency]
+	if !ok {
+		decimals = 2
+	}
+
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+
+	text := formatter.Number(math.Abs(value), decimals)
+	text = strings.Replace(formatter.data.CurrencyPattern, "#", text, 1)
+	text = strings.Replace(text, "¤", symbol, 1)
+	if value < 0 && math.Round(value*math.Pow10(decimals)) != 0 {
+		text = "-" + text
+	}
+	return text
+}
+
+func (formatter *localeFormatter) Date(t time.Time, length int) string {
+	length = max(FormatShort, min(length, FormatFull))
+	return formatter.pattern(t, formatter.data.DatePatterns[length])
+}
+
+func (formatter *localeFormatter) Time(t time.Time, length int) string {
+	length = max(FormatShort, min(length, FormatMedium))
+	return formatter.pattern(t, formatter.data.TimePatterns[length])
+}
+
+func (formatter *localeFormatter) DateTime(t time.Time, length int) string {
+	text := strings.Replace(formatter.data.DateTimePattern, "{date}", formatter.Date(t, length), 1)
+	return strings.Replace(text, "{time}", formatter.Time(t, length), 1)
+}
+
+func (formatter *localeFormatter) unitText(units map[string]string, unit string, n int64) string {
+	return FormatMessage(formatter.lang, units[unit], map[string]any{"n": n})
+}
+
+func (formatter *localeFormatter) RelativeTime(t time.Time, now time.Time) string {
+	d := t.Sub(now)
+	future := d >= 0
+	if !future {
+		d = -d
+	}
+
+	if d < time.Second {
+		return formatter.data.Now
+	}
+
+	var unit string
+	var n int64
+	switch days := int64(d / (24 * time.Hour)); {
+	case days >= 365:
+		unit, n = "year", days/365
+
+	case days >= 30:
+		unit, n = "month", days/30
+
+	case days >= 7:
+		unit, n = "week", days/7
+
+	case days >= 1:
+		unit, n = "day", days
+
+	case d >= time.Hour:
+		unit, n = "hour", int64(d/time.Hour)
+
+	case d >= time.Minute:
+		unit, n = "minute", int64(d/time.Minute)
+
+	default:
+		unit, n = "second", int64(d/time.Second)
+	}
+
+	text := formatter.unitText(formatter.data.Units, unit, n)
+	if future {
+		return strings.Replace(formatter.data.RelativeFuture, "{0}", text, 1)
+	}
+	return strings.Replace(formatter.data.RelativePast, "{0}", text, 1)
+}
+
+func (formatter *localeFormatter) Duration(d time.Duration) string {
+	units := formatter.data.DurationUnits
+	if units == nil {
+		units = formatter.data.Units
+	}
+
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	parts := []string{}
+	for _, item := range []struct {
+		unit     string
+		duration time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	} {
+		if n := int64(d / item.duration); n > 0 {
+			parts = append(parts, formatter.unitText(units, item.unit, n))
+			d -= time.Duration(n) * item.duration
+			if len(parts) == 2 {
+				break
+			}
+		} else if len(parts) > 0 {
+			break
+		}
+	}
+
+	if len(parts) == 0 {
+		return formatter.unitText(units, "second", 0)
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+func (formatter *localeFormatter) pattern(t time.Time, pattern string) string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	data := formatter.data
+	runes := []rune(pattern)
+	count := len(runes)
+
+	for i := 0; i < count; {
+		ch := runes[i]
+		if ch == '\'' {
+			i++
+			if i < count && runes[i] == '\'' {
+				buffer.WriteRune('\'')
+				i++
+				continue
+			}
+			for i < count && runes[i] != '\'' {
+				buffer.WriteRune(runes[i])
+				i++
+			}
+			i++
+			continue
+		}
+
+		if !strings.ContainsRune("yMdEHhmsa", ch) {
+			buffer.WriteRune(ch)
+			i++
+			continue
+		}
+
+		n := 1
+		for i+n < count && runes[i+n] == ch {
+			n++
+		}
+		i += n
+
+		number := func(value int) {
+			if n >= 2 {
+				fmt.Fprintf(buffer, "%02d", value)
+			} else {
+				buffer.WriteString(strconv.Itoa(value))
+			}
+		}
+
+		switch ch {
+		case 'y':
+			if n == 2 {
+				fmt.Fprintf(buffer, "%02d", t.Year()%100)
+			} else {
+				buffer.WriteString(strconv.Itoa(t.Year()))
+			}
+
+		case 'M':
+			switch n {
+			case 1, 2:
+				number(int(t.Month()))
+
+			case 3:
+				buffer.WriteString(data.ShortMonths[t.Month()-1])
+
+			default:
+				buffer.WriteString(data.Months[t.Month()-1])
+			}
+
+		case 'd':
+			number(t.Day())
+
+		case 'E':
+			if n >= 4 {
+				buffer.WriteString(data.Weekdays[t.Weekday()])
+			} else {
+				buffer.WriteString(data.ShortWeekdays[t.Weekday()])
+			}
+
+		case 'H':
+			number(t.Hour())
+
+		case 'h':
+			hour := t.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			number(hour)
+
+		case 'm':
+			number(t.Minute())
+
+		case 's':
+			number(t.Second())
+
+		case 'a':
+			if t.Hour() < 12 {
+				buffer.WriteString(data.DayPeriods[0])
+			} else {
+				buffer.WriteString(data.DayPeriods[1])
+			}
+		}
+	}
+
+	return buffer.String()
+}
+
+func formatLength(text string, defaultLength int) int {
+	switch text {
+	case "short":
+		return FormatShort
+
+	case "medium":
+		return FormatMedium
+
+	case "long":
+		return FormatLong
+
+	case "full":
+		return FormatFull
+	}
+	return defaultLength
+}
+
+// formatValueToTime converts the value to time.Time. Strings in RFC 3339, time.Time.String and
+// the date/time formats of DatePicker and TimePicker are supported
+func formatValueToTime(value any) (time.Time, bool) {
+	switch value := value.(type) {
+	case time.Time:
+		return value, true
+
+	case *time.Time:
+		if value != nil {
+			return *value, true
+		}
+
+	case string:
+		if index := strings.Index(value, " m="); index > 0 {
+			value = value[:index]
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999 -0700 MST", time.DateTime, time.DateOnly, "15:04:05", "15:04"} {
+			if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (formatter *localeFormatter) Format(value any, format string) string {
+	format = strings.TrimSpace(format)
+	name, param, _ := strings.Cut(format, ":")
+	name = strings.ToLower(strings.TrimSpace(name))
+	param = strings.TrimSpace(param)
+
+	toNumber := func() (float64, bool) {
+		if d, ok := value.(time.Duration); ok {
+			return d.Seconds(), true
+		}
+		return messageNumber(value)
+	}
+
+	decimals := func(defaultValue int) int {
+		if n, err := strconv.Atoi(param); err == nil && n >= 0 {
+			return n
+		}
+		return defaultValue
+	}
+
+	switch name {
+	case "number":
+		if n, ok := toNumber(); ok {
+			return formatter.Number(n, decimals(-1))
+		}
+
+	case "integer":
+		if n, ok := toNumber(); ok {
+			return formatter.Number(math.Round(n), 0)
+		}
+
+	case "percent":
+		if n, ok := toNumber(); ok {
+			return formatter.Percent(n, decimals(0))
+		}
+
+	case "currency":
+		if n, ok := toNumber(); ok {
+			return formatter.Currency(n, param)
+		}
+
+	case "date":
+		if t, ok := formatValueToTime(value); ok {
+			return formatter.Date(t, formatLength(param, FormatMedium))
+		}
+
+	case "time":
+		if t, ok := formatValueToTime(value); ok {
+			return formatter.Time(t, formatLength(param, FormatShort))
+		}
+
+	case "datetime":
+		if t, ok := formatValueToTime(value); ok {
+			return formatter.DateTime(t, formatLength(param, FormatMedium))
+		}
+
+	case "relative":
+		if t, ok := formatValueToTime(value); ok {
+			return formatter.RelativeTime(t, time.Now())
+		}
+
+	case "duration":
+		if d, ok := value.(time.Duration); ok {
+			return formatter.Duration(d)
+		}
+		if n, ok := messageNumber(value); ok {
+			return formatter.Duration(time.Duration(n * float64(time.Second)))
+		}
+
+	default:
+		if strings.ContainsRune(format, '{') {
+			return FormatMessage(formatter.lang, format, map[string]any{"value": value})
+		}
+		if t, ok := formatValueToTime(value); ok && format != "" {
+			return formatter.pattern(t, format)
+		}
+	}
+
+	return fmt.Sprint(value)
+}
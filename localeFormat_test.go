@@ -0,0 +1,78 @@
+package rui
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocaleFormatter(t *testing.T) {
+	createTestLog(t, false)
+
+	date := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	en := NewLocaleFormatter("en-US")
+	de := NewLocaleFormatter("de-AT")
+	ru := NewLocaleFormatter("ru")
+
+	tests := []struct{ result, expected string }{
+		{en.Language(), "en"},
+		{de.Language(), "de"},
+		{en.Number(1234567.891, 2), "1,234,567.89"},
+		{de.Number(-1234.5, -1), "-1.234,5"},
+		{ru.Number(1234, 0), "1\u00a0234"},
+		{en.Percent(0.256, 1), "25.6%"},
+		{en.Currency(-12.5, "USD"), "-$12.50"},
+		{de.Currency(1234, "EUR"), "1.234,00\u00a0€"},
+		{en.Date(date, FormatShort), "3/5/24"},
+		{en.Date(date, FormatFull), "Tuesday, March 5, 2024"},
+		{de.Date(date, FormatLong), "5. März 2024"},
+		{ru.Date(date, FormatLong), "5 марта 2024 г."},
+		{en.Time(date, FormatShort), "2:07 PM"},
+		{de.DateTime(date, FormatMedium), "05.03.2024, 14:07:09"},
+		{en.RelativeTime(date.Add(-3*time.Hour), date), "3 hours ago"},
+		{ru.RelativeTime(date.Add(25*time.Hour), date), "через 1 день"},
+		{ru.RelativeTime(date.Add(-5*time.Minute), date), "5 минут назад"},
+		{en.Duration(90 * time.Minute), "1 hour 30 minutes"},
+		{ru.Duration(2 * time.Minute), "2 минуты"},
+		{en.Format(3.14159, "number:2"), "3.14"},
+		{en.Format("0.5", "percent"), "50%"},
+		{en.Format(date, "yyyy-MM-dd HH:mm"), "2024-03-05 14:07"},
+		{en.Format(date, "date:long"), "March 5, 2024"},
+		{en.Format(2.5, "{value, number} kg"), "2.5 kg"},
+		{en.Format("abc", "number"), "abc"},
+	}
+
+	for i, test := range tests {
+		if test.result != test.expected {
+			t.Errorf(`test %d: result "%s", expected "%s"`, i, test.result, test.expected)
+		}
+	}
+}
+
+func TestSetLocaleDataConcurrency(t *testing.T) {
+	data := *NewLocaleFormatter("de").(*localeFormatter).data
+	data.DecimalSeparator = "'"
+	defer func() {
+		localeData.Lock()
+		delete(localeData.locales, "xx")
+		localeData.Unlock()
+	}()
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for range 100 {
+			SetLocaleData("xx", data)
+		}
+	}()
+
+	for range 100 {
+		NewLocaleFormatter("xx")
+	}
+	wait.Wait()
+
+	if text := NewLocaleFormatter("xx").Number(1.5, 1); text != "1'5" {
+		t.Errorf(`Number(1.5, 1) = "%s"`, text)
+	}
+}
@@ -840,24 +840,24 @@ func setMediaPlayerSource(properties Properties, value any) []PropertyName {
 */
 func mediaPlayerEvents() map[PropertyName]string {
 	return map[PropertyName]string{
-		AbortEvent:          "onabort",
-		CanPlayEvent:        "oncanplay",
-		CanPlayThroughEvent: "oncanplaythrough",
-		CompleteEvent:       "oncomplete",
-		EmptiedEvent:        "onemptied",
-		EndedEvent:          "ended",
-		LoadedDataEvent:     "onloadeddata",
-		LoadedMetadataEvent: "onloadedmetadata",
-		LoadStartEvent:      "onloadstart",
-		PauseEvent:          "onpause",
-		PlayEvent:           "onplay",
-		PlayingEvent:        "onplaying",
-		ProgressEvent:       "onprogress",
-		SeekedEvent:         "onseeked",
-		SeekingEvent:        "onseeking",
-		StalledEvent:        "onstalled",
-		SuspendEvent:        "onsuspend",
-		WaitingEvent:        "onwaiting",
+		AbortEvent:          "data-onabort",
+		CanPlayEvent:        "data-oncanplay",
+		CanPlayThroughEvent: "data-oncanplaythrough",
+		CompleteEvent:       "data-oncomplete",
+		EmptiedEvent:        "data-onemptied",
+		EndedEvent:          "data-onended",
+		LoadedDataEvent:     "data-onloadeddata",
+		LoadedMetadataEvent: "data-onloadedmetadata",
+		LoadStartEvent:      "data-onloadstart",
+		PauseEvent:          "data-onpause",
+		PlayEvent:           "data-onplay",
+		PlayingEvent:        "data-onplaying",
+		ProgressEvent:       "data-onprogress",
+		SeekedEvent:         "data-onseeked",
+		SeekingEvent:        "data-onseeking",
+		StalledEvent:        "data-onstalled",
+		SuspendEvent:        "data-onsuspend",
+		WaitingEvent:        "data-onwaiting",
 	}
 }
 
@@ -898,37 +898,37 @@ func (player *mediaPlayerData) propertyChanged(tag PropertyName) {
 
 	case TimeUpdateEvent:
 		if value := player.getRaw(tag); value != nil {
-			session.updateProperty(player.htmlID(), "ontimeupdate", "viewTimeUpdatedEvent(this)")
+			session.updateProperty(player.htmlID(), "data-ontimeupdate", "viewTimeUpdatedEvent(this)")
 		} else {
-			session.updateProperty(player.htmlID(), "ontimeupdate", "")
+			session.updateProperty(player.htmlID(), "data-ontimeupdate", "")
 		}
 
 	case VolumeChangedEvent:
 		if value := player.getRaw(tag); value != nil {
-			session.updateProperty(player.htmlID(), "onvolumechange", "viewVolumeChangedEvent(this)")
+			session.updateProperty(player.htmlID(), "data-onvolumechange", "viewVolumeChangedEvent(this)")
 		} else {
-			session.updateProperty(player.htmlID(), "onvolumechange", "")
+			session.updateProperty(player.htmlID(), "data-onvolumechange", "")
 		}
 
 	case DurationChangedEvent:
 		if value := player.getRaw(tag); value != nil {
-			session.updateProperty(player.htmlID(), "ondurationchange", "viewDurationChangedEvent(this)")
+			session.updateProperty(player.htmlID(), "data-ondurationchange", "viewDurationChangedEvent(this)")
 		} else {
-			session.updateProperty(player.htmlID(), "ondurationchange", "")
+			session.updateProperty(player.htmlID(), "data-ondurationchange", "")
 		}
 
 	case RateChangedEvent:
 		if value := player.getRaw(tag); value != nil {
-			session.updateProperty(player.htmlID(), "onratechange", "viewRateChangedEvent(this)")
+			session.updateProperty(player.htmlID(), "data-onratechange", "viewRateChangedEvent(this)")
 		} else {
-			session.updateProperty(player.htmlID(), "onratechange", "")
+			session.updateProperty(player.htmlID(), "data-onratechange", "")
 		}
 
 	case PlayerErrorEvent:
 		if value := player.getRaw(tag); value != nil {
-			session.updateProperty(player.htmlID(), "onerror", "viewErrorEvent(this)")
+			session.updateProperty(player.htmlID(), "data-onerror", "viewErrorEvent(this)")
 		} else {
-			session.updateProperty(player.htmlID(), "onerror", "")
+			session.updateProperty(player.htmlID(), "data-onerror", "")
 		}
 
 	case Source:
@@ -988,23 +988,23 @@ func (player *mediaPlayerData) htmlProperties(self View, buffer *strings.Builder
 	}
 
 	if value := player.getRaw(TimeUpdateEvent); value != nil {
-		buffer.WriteString(` ontimeupdate="playerTimeUpdatedEvent(this)"`)
+		buffer.WriteString(` data-ontimeupdate="playerTimeUpdatedEvent(this)"`)
 	}
 
 	if value := player.getRaw(VolumeChangedEvent); value != nil {
-		buffer.WriteString(` onvolumechange="playerVolumeChangedEvent(this)"`)
+		buffer.WriteString(` data-onvolumechange="playerVolumeChangedEvent(this)"`)
 	}
 
 	if value := player.getRaw(DurationChangedEvent); value != nil {
-		buffer.WriteString(` ondurationchange="playerDurationChangedEvent(this)"`)
+		buffer.WriteString(` data-ondurationchange="playerDurationChangedEvent(this)"`)
 	}
 
 	if value := player.getRaw(RateChangedEvent); value != nil {
-		buffer.WriteString(` onratechange="playerRateChangedEvent(this)"`)
+		buffer.WriteString(` data-onratechange="playerRateChangedEvent(this)"`)
 	}
 
 	if value := player.getRaw(PlayerErrorEvent); value != nil {
-		buffer.WriteString(` onerror="playerErrorEvent(this)"`)
+		buffer.WriteString(` data-onerror="playerErrorEvent(this)"`)
 	}
 }
 
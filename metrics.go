@@ -0,0 +1,274 @@
+//go:build !wasm
+
+package rui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// metricsEventCommands contains the commands of the client events which are counted separately,
+// the unknown commands are counted as "other"
+var metricsEventCommands = func() map[string]bool {
+	result := map[string]bool{}
+	for _, command := range []string{
+		"start-session", "session-pause", "session-resume", "timer", "root-size", "resize", "sessionInfo",
+		"inspector-pick", "server-shutdown", "cellClick", "currentCell", "currentRow", "details-open",
+		"fileLoaded", "fileLoadingError", "fileSelected", "heightChanged", "imageViewError", "imageViewLoaded",
+		"itemClick", "itemSelected", "itemUnselected", "rowClick", "scroll", "tabClick", "tabCloseClick",
+		"textChanged", "widthChanged",
+	} {
+		result[command] = true
+	}
+	for _, event := range []PropertyName{
+		AbortEvent, AnimationCancelEvent, AnimationEndEvent, AnimationIterationEvent, AnimationStartEvent,
+		CanPlayEvent, CanPlayThroughEvent, ClickEvent, CompleteEvent, ContextMenuEvent, DoubleClickEvent,
+		DragEndEvent, DragEnterEvent, DragLeaveEvent, DragOverEvent, DragStartEvent, DropEvent,
+		DurationChangedEvent, FocusEvent, KeyDownEvent, KeyUpEvent, LoadStartEvent, LostFocusEvent, MouseDown,
+		MouseMove, MouseOut, MouseOver, MouseUp, PlayerErrorEvent, PointerCancel, PointerDown, PointerMove,
+		PointerOut, PointerOver, PointerUp, RateChangedEvent, TimeUpdateEvent, TouchCancel, TouchEnd, TouchMove,
+		TouchStart, TransitionCancelEvent, TransitionEndEvent, TransitionRunEvent, TransitionStartEvent,
+		VolumeChangedEvent,
+	} {
+		result[string(event)] = true
+	}
+	return result
+}()
+
+// metricsLabelEscaper escapes the label values of the Prometheus text format
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricsHistogram is the cumulative histogram in the Prometheus format
+type metricsHistogram struct {
+	mutex   sync.Mutex
+	bounds  []float64
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// appMetrics collects the instrumentation of the application (see AppParams.Metrics)
+type appMetrics struct {
+	connects      atomic.Int64
+	reconnects    atomic.Int64
+	disconnects   atomic.Int64
+	downloadBytes atomic.Int64
+	uploadBytes   atomic.Int64
+	eventsMutex   sync.Mutex
+	events        map[string]int64
+	received      metricsHistogram
+	sent          metricsHistogram
+	answerLatency metricsHistogram
+}
+
+func newMetricsHistogram(bounds ...float64) metricsHistogram {
+	return metricsHistogram{
+		bounds:  bounds,
+		buckets: make([]uint64, len(bounds)),
+	}
+}
+
+func newAppMetrics() *appMetrics {
+	return &appMetrics{
+		events:        map[string]int64{},
+		received:      newMetricsHistogram(64, 256, 1024, 4096, 16384, 65536, 262144, 1048576),
+		sent:          newMetricsHistogram(64, 256, 1024, 4096, 16384, 65536, 262144, 1048576),
+		answerLatency: newMetricsHistogram(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5),
+	}
+}
+
+func (histogram *metricsHistogram) observe(value float64) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	histogram.count++
+	histogram.sum += value
+	for i, bound := range histogram.bounds {
+		if value <= bound {
+			histogram.buckets[i]++
+		}
+	}
+}
+
+func (histogram *metricsHistogram) write(w io.Writer, name, help string) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	for i, bound := range histogram.bounds {
+		fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", name, strconv.FormatFloat(bound, 'g', -1, 64), histogram.buckets[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, histogram.count)
+	fmt.Fprintf(w, "%s_sum %s\n", name, strconv.FormatFloat(histogram.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%s_count %d\n", name, histogram.count)
+}
+
+// The methods of appMetrics can be called for nil (if the metrics are disabled)
+
+func (metrics *appMetrics) connected() {
+	if metrics != nil {
+		metrics.connects.Add(1)
+	}
+}
+
+func (metrics *appMetrics) reconnected() {
+	if metrics != nil {
+		metrics.reconnects.Add(1)
+	}
+}
+
+func (metrics *appMetrics) disconnected() {
+	if metrics != nil {
+		metrics.disconnects.Add(1)
+	}
+}
+
+func (metrics *appMetrics) event(command string) {
+	if metrics != nil {
+		metrics.eventsMutex.Lock()
+		defer metrics.eventsMutex.Unlock()
+
+		if !metricsEventCommands[command] {
+			command = "other"
+		}
+		metrics.events[command]++
+	}
+}
+
+func (metrics *appMetrics) messageReceived(size int) {
+	if metrics != nil {
+		metrics.received.observe(float64(size))
+	}
+}
+
+func (metrics *appMetrics) messageSent(size int) {
+	if metrics != nil {
+		metrics.sent.observe(float64(size))
+	}
+}
+
+func (metrics *appMetrics) answerReceived(latency time.Duration) {
+	if metrics != nil {
+		metrics.answerLatency.observe(latency.Seconds())
+	}
+}
+
+func (metrics *appMetrics) downloaded(size int64) {
+	if metrics != nil {
+		metrics.downloadBytes.Add(size)
+	}
+}
+
+func (metrics *appMetrics) uploaded(size int64) {
+	if metrics != nil {
+		metrics.uploadBytes.Add(size)
+	}
+}
+
+// receivedFileSize returns the number of bytes of the file data received with the "fileLoaded" event
+func receivedFileSize(data DataObject) int64 {
+	base64Data, ok := data.PropertyValue("data")
+	if !ok {
+		return 0
+	}
+	if index := strings.LastIndex(base64Data, ","); index >= 0 {
+		base64Data = base64Data[index+1:]
+	}
+	padding := strings.Count(base64Data[max(len(base64Data)-2, 0):], "=")
+	return int64(base64.StdEncoding.DecodedLen(len(base64Data)) - padding)
+}
+
+// writeMetrics writes the metrics of the application in the Prometheus text format
+func (app *application) writeMetrics(w io.Writer) {
+	metrics := app.metrics
+	sessions := len(app.sessionList())
+	goroutines := runtime.NumGoroutine()
+
+	gauge := func(name, help string, value any) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", name, help, name, name, value)
+	}
+	counter := func(name, help string, value int64) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", name, help, name, name, value)
+	}
+
+	gauge("rui_sessions_active", "Number of active sessions.", sessions)
+	gauge("rui_goroutines", "Number of goroutines.", goroutines)
+	if sessions > 0 {
+		gauge("rui_goroutines_per_session", "Number of goroutines divided by the number of active sessions.",
+			strconv.FormatFloat(float64(goroutines)/float64(sessions), 'f', 2, 64))
+	}
+
+	counter("rui_connects_total", "Number of created sessions.", metrics.connects.Load())
+	counter("rui_reconnects_total", "Number of reconnections to existing sessions.", metrics.reconnects.Load())
+	counter("rui_disconnects_total", "Number of lost connections.", metrics.disconnects.Load())
+	counter("rui_download_bytes_total", "Number of bytes of downloaded files.", metrics.downloadBytes.Load())
+	counter("rui_upload_bytes_total", "Number of bytes of files loaded from clients.", metrics.uploadBytes.Load())
+
+	metrics.eventsMutex.Lock()
+	commands := make([]string, 0, len(metrics.events))
+	for command := range metrics.events {
+		commands = append(commands, command)
+	}
+	slices.Sort(commands)
+
+	fmt.Fprint(w, "# HELP rui_events_total Number of client events by command.\n# TYPE rui_events_total counter\n")
+	for _, command := range commands {
+		fmt.Fprintf(w, "rui_events_total{command=\"%s\"} %d\n", metricsLabelEscaper.Replace(command), metrics.events[command])
+	}
+	metrics.eventsMutex.Unlock()
+
+	metrics.received.write(w, "rui_received_message_bytes", "Size of the messages received from clients.")
+	metrics.sent.write(w, "rui_sent_message_bytes", "Size of the messages sent to clients.")
+	metrics.answerLatency.write(w, "rui_answer_latency_seconds", "Round-trip time of the requests of values from clients.")
+}
+
+// serveMonitoring handles the "/metrics" and "/healthz" requests. Returns false if the endpoint is disabled
+func (app *application) serveMonitoring(w http.ResponseWriter, path string) bool {
+	switch path {
+	case "/metrics":
+		if !app.params.Metrics {
+			return false
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		app.writeMetrics(w)
+
+	case "/healthz":
+		if !app.params.HealthCheck {
+			return false
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if app.shuttingDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, "shutting down\n")
+		} else {
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, "ok\n")
+		}
+
+	default:
+		return false
+	}
+	return true
+}
+
+// countingResponseWriter counts the bytes of the response body
+type countingResponseWriter struct {
+	http.ResponseWriter
+	size int64
+}
+
+func (writer *countingResponseWriter) Write(data []byte) (int, error) {
+	n, err := writer.ResponseWriter.Write(data)
+	writer.size += int64(n)
+	return n, err
+}
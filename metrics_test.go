@@ -0,0 +1,81 @@
+package rui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	app := createTestApp(t, AppParams{Metrics: true, HealthCheck: true})
+
+	bridge := createHttpBridge(httptest.NewRequest(http.MethodPost, "/", nil))
+	bridge.metrics = app.metrics
+	params, _ := ParseDataText("start-session{session=9}")
+	if app.createSession(params, nil, bridge, bridge.response, nil, "") == nil {
+		t.Fatal("the session is not created")
+	}
+	bridge.writeMessage("reloadPage();")
+	app.metrics.event("click-event")
+	app.metrics.event("unknown-1")
+	app.metrics.event("unknown-2\"\n")
+
+	data, _ := ParseDataText(`fileLoaded{id=view, data="data:text/plain;base64,SGVsbG8=", name=a.txt, size=1000000}`)
+	app.metrics.uploaded(receivedFileSize(data))
+	app.metrics.answerReceived(30 * time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("/metrics status = %d", recorder.Code)
+	}
+
+	text := recorder.Body.String()
+	for _, line := range []string{
+		"rui_sessions_active 1",
+		"rui_connects_total 1",
+		`rui_events_total{command="click-event"} 1`,
+		`rui_events_total{command="other"} 2`,
+		"rui_upload_bytes_total 5",
+		`rui_sent_message_bytes_bucket{le="64"} 1`,
+		`rui_answer_latency_seconds_bucket{le="0.025"} 0`,
+		`rui_answer_latency_seconds_bucket{le="0.05"} 1`,
+		"rui_answer_latency_seconds_count 1",
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("the metrics do not contain %q", line)
+		}
+	}
+
+	if strings.Contains(text, "unknown") {
+		t.Error("the metrics contain the unknown commands")
+	}
+
+	recorder = httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("/healthz status = %d", recorder.Code)
+	}
+
+	app.shuttingDown.Store(true)
+	recorder = httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("/healthz status = %d while shutting down", recorder.Code)
+	}
+
+	app.params.Metrics = false
+	recorder = httptest.NewRecorder()
+	app.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("/metrics status = %d when the metrics are disabled", recorder.Code)
+	}
+}
+
+func TestMetricsLabelEscaper(t *testing.T) {
+	if text := metricsLabelEscaper.Replace("a\\b\"c\nd\te"); text != `a\\b\"c\nd`+"\te" {
+		t.Errorf("metricsLabelEscaper.Replace result: %s", text)
+	}
+}
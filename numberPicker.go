@@ -207,10 +207,16 @@ func (picker *numberPickerData) propertyChanged(tag PropertyName) {
 			picker.Session().updateProperty(picker.htmlID(), "step", "any")
 		}
 
+	case Format:
+		picker.updateValueText()
+
 	case NumberPickerValue:
 		value := GetNumberPickerValue(picker)
 		format := picker.numberFormat()
 		picker.Session().callFunc("setInputValue", picker.htmlID(), fmt.Sprintf(format, value))
+		if text := picker.valueText(); text != "" {
+			picker.Session().updateProperty(picker.htmlID(), "aria-valuetext", text)
+		}
 
 		if listeners := getTwoArgEventListeners[NumberPicker, float64](picker, nil, NumberChangedEvent); len(listeners) > 0 {
 			old := 0.0
@@ -231,6 +237,22 @@ func (picker *numberPickerData) propertyChanged(tag PropertyName) {
 	}
 }
 
+// valueText returns the value formatted by the "format" property or "" if the property is not set
+func (picker *numberPickerData) valueText() string {
+	if format := viewFormat(picker); format != "" {
+		return picker.Session().Formatter().Format(GetNumberPickerValue(picker), format)
+	}
+	return ""
+}
+
+func (picker *numberPickerData) updateValueText() {
+	if text := picker.valueText(); text != "" {
+		picker.Session().updateProperty(picker.htmlID(), "aria-valuetext", text)
+	} else {
+		picker.Session().removeProperty(picker.htmlID(), "aria-valuetext")
+	}
+}
+
 func (picker *numberPickerData) htmlTag() string {
 	return "input"
 }
@@ -278,7 +300,13 @@ func (picker *numberPickerData) htmlProperties(self View, buffer *strings.Builde
 	fmt.Fprintf(buffer, format, GetNumberPickerValue(picker))
 	buffer.WriteByte('"')
 
-	buffer.WriteString(` oninput="editViewInputEvent(this)"`)
+	if text := picker.valueText(); text != "" {
+		buffer.WriteString(` aria-valuetext="`)
+		buffer.WriteString(textToHtml(text))
+		buffer.WriteByte('"')
+	}
+
+	buffer.WriteString(` data-oninput="editViewInputEvent(this)"`)
 
 	dataListHtmlProperties(picker, buffer)
 }
@@ -330,6 +330,8 @@ type Popup interface {
 
 	onDismiss()
 	html(hidden bool) string
+	htmlLayerID() string
+	layer() View
 	viewByHTMLID(id string) View
 	keyEvent(event KeyEvent) bool
 	showAnimation()
@@ -828,8 +830,17 @@ func (popup *popupData) propertyChanged(tag PropertyName) {
 
 	case Title, CloseButton:
 		popup.popupView.RemoveViewByID(popupTitleID)
+		labelID := ""
 		if view := popup.createTitleView(); view != nil {
 			popup.popupView.Append(view)
+			labelID = popup.titleLabelID(view)
+		}
+		if popup.getRaw(AriaLabelledBy) == nil {
+			if labelID != "" {
+				popup.popupView.Set(AriaLabelledBy, labelID)
+			} else {
+				popup.popupView.Remove(AriaLabelledBy)
+			}
 		}
 
 	case TitleStyle:
@@ -1097,8 +1108,8 @@ func (popup *popupData) showAnimation() {
 		htmlID := popup.layerView.htmlID()
 
 		session := popup.Session()
-		session.updateProperty(htmlID, "ontransitionend", "scanElementsSize()")
-		session.updateProperty(htmlID, "ontransitioncancel", "scanElementsSize()")
+		session.updateProperty(htmlID, "data-ontransitionend", "scanElementsSize()")
+		session.updateProperty(htmlID, "data-ontransitioncancel", "scanElementsSize()")
 
 		animation := popup.animationProperty()
 		if opacity != 1 {
@@ -1155,6 +1166,21 @@ func (popup *popupData) html(hidden bool) string {
 	return buffer.String()
 }
 
+func (popup *popupData) htmlLayerID() string {
+	if popup.layerView != nil {
+		return popup.layerView.htmlID()
+	}
+	return ""
+}
+
+// layer returns the root view of the popup (the layer which contains the popup window)
+func (popup *popupData) layer() View {
+	if popup.layerView != nil {
+		return popup.layerView
+	}
+	return nil
+}
+
 func (popup *popupData) viewByHTMLID(id string) View {
 	if popup.layerView != nil {
 		return viewByHTMLID(id, popup.layerView)
@@ -1164,7 +1190,9 @@ func (popup *popupData) viewByHTMLID(id string) View {
 
 func (popup *popupData) onDismiss() {
 	if popup.layerView != nil {
-		popup.Session().callFunc("removeView", popup.layerView.htmlID())
+		session := popup.Session()
+		session.callFunc("releaseFocus", popup.layerView.htmlID())
+		session.callFunc("removeView", popup.layerView.htmlID())
 		popup.layerView = nil
 
 		if value := popup.getRaw(DismissEvent); value != nil {
@@ -1311,6 +1339,8 @@ func (popup *popupData) createTitleView() GridLayout {
 			Content:             "✕",
 			NotTranslate:        true,
 			ClickEvent:          popup.cancel,
+			Role:                "button",
+			AriaLabel:           "Close",
 		})
 	}
 
@@ -1360,6 +1390,16 @@ func (popup *popupData) createTitleView() GridLayout {
 	})
 }
 
+// titleLabelID returns the HTML ID of the title view (without the close button) which is used as the accessible name of the popup
+func (popup *popupData) titleLabelID(titleView GridLayout) string {
+	if popup.getRaw(Title) != nil {
+		if views := titleView.Views(); len(views) > 0 {
+			return views[0].htmlID()
+		}
+	}
+	return ""
+}
+
 func (popup *popupData) createContentContainer() ColumnLayout {
 	params := Params{
 		ID:     popupContentID,
@@ -1428,6 +1468,9 @@ func (popup *popupData) createLayerView() GridLayout {
 		CellHorizontalAlign,
 	}
 
+	params[Role] = "dialog"
+	params[AriaModal] = true
+
 	popup.mutex.Lock()
 	for tag, value := range popup.properties {
 		if !slices.Contains(popupProperties, tag) {
@@ -1439,6 +1482,9 @@ func (popup *popupData) createLayerView() GridLayout {
 	views := make([]View, 0, 3)
 	if title := popup.createTitleView(); title != nil {
 		views = append(views, title)
+		if labelID := popup.titleLabelID(title); labelID != "" && popup.getRaw(AriaLabelledBy) == nil {
+			params[AriaLabelledBy] = labelID
+		}
 	}
 
 	views = append(views, popup.createContentContainer())
@@ -1594,7 +1640,7 @@ func CreatePopupFromResources(session Session, name string, binding any) Popup {
 
 	createEmbed := func(fs *embed.FS, path string) Popup {
 		if data, err := fs.ReadFile(path); err == nil {
-			data, err := ParseDataText(string(data))
+			data, err := ParseDataFile(path, string(data))
 			if err == nil {
 				return CreatePopupFromObject(session, data, binding)
 			}
@@ -1637,7 +1683,7 @@ func CreatePopupFromResources(session Session, name string, binding any) Popup {
 
 	createFromFile := func(path string) Popup {
 		if data, err := os.ReadFile(path); err == nil {
-			data, err := ParseDataText(string(data))
+			data, err := ParseDataFile(path, string(data))
 			if err == nil {
 				return CreatePopupFromObject(session, data, binding)
 			}
@@ -1686,8 +1732,11 @@ func (manager *popupManager) showPopup(popup Popup) {
 	manager.mutex.Unlock()
 
 	session := popup.Session()
-	session.callFunc("blurCurrent")
 	session.appendToInnerHTML(popupLayerID, popup.html(false))
+	if layerID := popup.htmlLayerID(); layerID != "" {
+		// the focus is moved into the popup and restored after its dismissal
+		session.callFunc("trapFocus", layerID)
+	}
 
 	session.updateCSSProperty("ruiTooltipLayer", "visibility", "hidden")
 	session.updateCSSProperty("ruiTooltipLayer", "opacity", "0")
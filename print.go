@@ -0,0 +1,93 @@
+package rui
+
+import (
+	"html"
+	"strings"
+)
+
+// Constants of the theme which define the page of the printed document
+const (
+	// PrintPageSizeConstant is the name of the theme constant which specifies the page size of the printed document,
+	// e.g. "A4", "letter landscape", "210mm 297mm" (the value of the CSS "size" descriptor of the @page rule)
+	PrintPageSizeConstant = "ruiPrintPageSize"
+
+	// PrintPageMarginConstant is the name of the theme constant which specifies the page margins of the printed document,
+	// e.g. "1cm", "2cm 1.5cm" (the value of the CSS "margin" descriptor of the @page rule)
+	PrintPageMarginConstant = "ruiPrintPageMargin"
+)
+
+// pageCSS returns the @page rule built from the print constants of the theme or "" if they are not set
+func pageCSS(theme Theme, session Session) string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	for _, item := range []struct {
+		constant string
+		css      string
+	}{
+		{PrintPageSizeConstant, "size"},
+		{PrintPageMarginConstant, "margin"},
+	} {
+		value := theme.constant(item.constant, session.TouchScreen())
+		if value == "" {
+			continue
+		}
+
+		if strings.ContainsRune(value, '@') {
+			var ok bool
+			if value, ok = session.resolveConstants(value); !ok {
+				continue
+			}
+		}
+
+		buffer.WriteString("\t")
+		buffer.WriteString(item.css)
+		buffer.WriteString(": ")
+		buffer.WriteString(value)
+		buffer.WriteString(";\n")
+	}
+
+	if buffer.Len() == 0 {
+		return ""
+	}
+	return "@page {\n" + buffer.String() + "}\n"
+}
+
+func (session *sessionData) Print(view View) {
+	if view != nil {
+		session.callFunc("printView", view.htmlID())
+	} else {
+		session.callFunc("printView", "")
+	}
+}
+
+// RenderHTMLDocument returns the standalone HTML document which contains the view (and its children),
+// the application styles and the styles of the current theme of the session. The document does not contain
+// scripts, so it can be saved for archival. Resources (images, fonts) are referenced by their URLs.
+func RenderHTMLDocument(view View, title string) string {
+	if view == nil {
+		return ""
+	}
+
+	session := view.Session()
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteString("<!DOCTYPE html>\n<html")
+	if lang := session.Language(); lang != "" {
+		buffer.WriteString(` lang="`)
+		buffer.WriteString(lang)
+		buffer.WriteString(`"`)
+	}
+	buffer.WriteString(">\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	title, _ = session.GetString(title)
+	buffer.WriteString(html.EscapeString(title))
+	buffer.WriteString("</title>\n<style>\n")
+	buffer.WriteString(appStyles)
+	buffer.WriteString(unescapeScriptCSS(session.getCurrentTheme().cssText(session)))
+	buffer.WriteString("</style>\n</head>\n<body class=\"ruiDocument\">\n")
+	viewHTML(view, buffer, "")
+	buffer.WriteString("\n</body>\n</html>")
+
+	return buffer.String()
+}
@@ -0,0 +1,34 @@
+package rui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTMLDocument(t *testing.T) {
+	session := newSession(nil, 0, "", nil).(*sessionData)
+	theme := NewTheme("print")
+	theme.SetConstant(PrintPageSizeConstant, "A4 landscape", "")
+	theme.SetConstant(PrintPageMarginConstant, "@pageMargin", "")
+	theme.SetConstant("pageMargin", "1cm", "")
+	session.customTheme = theme
+
+	view := NewTextView(session, Params{Text: "Report", AvoidBreak: true})
+	text := RenderHTMLDocument(view, "Report <1>")
+
+	for _, expected := range []string{
+		"<title>Report &lt;1&gt;</title>",
+		"\tsize: A4 landscape;\n\tmargin: 1cm;\n",
+		`id="` + view.htmlID() + `"`,
+		"Report</div>",
+		`<body class="ruiDocument">`,
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("the document does not contain %q", expected)
+		}
+	}
+
+	if strings.Contains(text, `\n`) || strings.Contains(text, `\t`) {
+		t.Error(`the styles of the document contain the escaped "\n" or "\t"`)
+	}
+}
@@ -2716,5 +2716,44 @@ const (
 	// Supported types: string.
 	Tooltip PropertyName = "tooltip"
 
+	// Format is the constant for "format" property tag.
+	//
+	// Used by TextView, TableView, NumberPicker.
+	// Specifies the locale-aware format of a number, a date/time or a duration (see LocaleFormatter.Format).
+	// The locale is defined by the language chain of the session. Examples: "number:2", "percent", "currency:EUR",
+	// "date:long", "datetime:short", "relative", "duration", "{value, number} kg".
+	//
+	// TextView formats the "text" property value. TableView formats the numeric and time.Time cell values, a list of formats
+	// separated by semicolons (or an array of strings) sets the format for each column. NumberPicker uses the format
+	// for the accessible value text (aria-valuetext).
+	//
+	// The format is treated as a string resource tag unless the "not-translate" property is true.
+	//
+	// Supported types: string.
+	Format PropertyName = "format"
+
+	// ContainerName is the constant for "container-name" property tag.
+	//
+	// Used by View.
+	// Makes the view a query container with the given name. The media styles with the "container-<name>" condition
+	// (see MediaStyleParams.Container) are applied to the children of the view depending on its size.
+	// If the "container-type" property is not set then the "inline-size" type is used.
+	//
+	// Supported types: string.
+	ContainerName PropertyName = "container-name"
+
+	// ContainerType is the constant for "container-type" property tag.
+	//
+	// Used by View.
+	// Specifies the dimensions of the view which are used by the container queries.
+	//
+	// Supported types: int, string.
+	//
+	// Values:
+	//   - 0 (ContainerNormal) or "normal" - The view is not a size container.
+	//   - 1 (ContainerInlineSize) or "inline-size" - The width (inline size) of the view can be queried.
+	//   - 2 (ContainerSize) or "size" - The width and the height of the view can be queried.
+	ContainerType PropertyName = "container-type"
+
 	Binding PropertyName = "binding"
 )
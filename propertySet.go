@@ -1,6 +1,7 @@
 package rui
 
 import (
+	"fmt"
 	"math"
 	"slices"
 	"strconv"
@@ -56,6 +57,8 @@ var boolProperties = []PropertyName{
 	ColumnSpanAll,
 	MoveToFrontAnimation,
 	HideSummaryMarker,
+	AriaHidden,
+	AriaModal,
 }
 
 var intProperties = []PropertyName{
@@ -176,6 +179,16 @@ var enumProperties = map[PropertyName]enumPropertyData{
 		"",
 		[]string{"div", "article", "section", "aside", "header", "main", "footer", "nav", "figure", "figcaption", "button", "p", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "code"},
 	},
+	ContainerType: {
+		[]string{"normal", "inline-size", "size"},
+		string(ContainerType),
+		[]string{"normal", "inline-size", "size"},
+	},
+	AriaLive: {
+		[]string{"off", "polite", "assertive"},
+		"",
+		[]string{"off", "polite", "assertive"},
+	},
 	Visibility: {
 		[]string{"visible", "invisible", "gone"},
 		"",
@@ -498,7 +511,12 @@ func notCompatibleType(tag PropertyName, value any) {
 }
 
 func invalidPropertyValue(tag PropertyName, value any) {
-	ErrorLogF(`Invalid value "%v" of "%s" property`, value, string(tag))
+	ErrorLog(invalidPropertyValueText(tag, value))
+}
+
+// invalidPropertyValueText returns the description of the error of setting the value of the property
+func invalidPropertyValueText(tag PropertyName, value any) string {
+	return fmt.Sprintf(`Invalid value "%v" of "%s" property`, value, string(tag))
 }
 
 func isConstantName(text string) (bool, string) {
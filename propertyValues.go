@@ -412,4 +412,13 @@ const (
 	// is computationally expensive, this value is only supported for blocks of text
 	// spanning a limited number of lines (six or less for Chromium and ten or less for Firefox).
 	TextWrapBalance = 2
+
+	// ContainerNormal - value of the "container-type" property: the view is not a size container
+	ContainerNormal = 0
+
+	// ContainerInlineSize - value of the "container-type" property: the width (inline size) of the view can be queried
+	ContainerInlineSize = 1
+
+	// ContainerSize - value of the "container-type" property: the width and the height of the view can be queried
+	ContainerSize = 2
 )
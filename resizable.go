@@ -377,7 +377,7 @@ func (resizable *resizableData) htmlSubviews(self View, buffer *strings.Builder)
 		top = 2
 
 		if leftSide {
-			buffer.WriteString(`<div onmousedown="startResize(this, -1, -1, event)" style="cursor: nwse-resize; width: `)
+			buffer.WriteString(`<div data-onmousedown="startResize(this, -1, -1, event)" style="cursor: nwse-resize; width: `)
 			buffer.WriteString(w)
 			buffer.WriteString(`; height: `)
 			buffer.WriteString(w)
@@ -385,13 +385,13 @@ func (resizable *resizableData) htmlSubviews(self View, buffer *strings.Builder)
 			writePos(1, 2, 1, 2)
 		}
 
-		buffer.WriteString(`<div onmousedown="startResize(this, 0, -1, event)" style="cursor: ns-resize; width: 100%; height: `)
+		buffer.WriteString(`<div data-onmousedown="startResize(this, 0, -1, event)" style="cursor: ns-resize; width: 100%; height: `)
 		buffer.WriteString(w)
 		buffer.WriteString(`;`)
 		writePos(left, left+1, 1, 2)
 
 		if rightSide {
-			buffer.WriteString(`<div onmousedown="startResize(this, 1, -1, event)" style="cursor: nesw-resize; width: `)
+			buffer.WriteString(`<div data-onmousedown="startResize(this, 1, -1, event)" style="cursor: nesw-resize; width: `)
 			buffer.WriteString(w)
 			buffer.WriteString(`; height: `)
 			buffer.WriteString(w)
@@ -401,14 +401,14 @@ func (resizable *resizableData) htmlSubviews(self View, buffer *strings.Builder)
 	}
 
 	if leftSide {
-		buffer.WriteString(`<div onmousedown="startResize(this, -1, 0, event)" style="cursor: ew-resize; width: `)
+		buffer.WriteString(`<div data-onmousedown="startResize(this, -1, 0, event)" style="cursor: ew-resize; width: `)
 		buffer.WriteString(w)
 		buffer.WriteString(`; height: 100%;`)
 		writePos(1, 2, top, top+1)
 	}
 
 	if rightSide {
-		buffer.WriteString(`<div onmousedown="startResize(this, 1, 0, event)" style="cursor: ew-resize; width: `)
+		buffer.WriteString(`<div data-onmousedown="startResize(this, 1, 0, event)" style="cursor: ew-resize; width: `)
 		buffer.WriteString(w)
 		buffer.WriteString(`; height: 100%;`)
 		writePos(left+1, left+2, top, top+1)
@@ -416,7 +416,7 @@ func (resizable *resizableData) htmlSubviews(self View, buffer *strings.Builder)
 
 	if (side & BottomSide) != 0 {
 		if leftSide {
-			buffer.WriteString(`<div onmousedown="startResize(this, -1, 1, event)" style="cursor: nesw-resize; width: `)
+			buffer.WriteString(`<div data-onmousedown="startResize(this, -1, 1, event)" style="cursor: nesw-resize; width: `)
 			buffer.WriteString(w)
 			buffer.WriteString(`; height: `)
 			buffer.WriteString(w)
@@ -424,13 +424,13 @@ func (resizable *resizableData) htmlSubviews(self View, buffer *strings.Builder)
 			writePos(1, 2, top+1, top+2)
 		}
 
-		buffer.WriteString(`<div onmousedown="startResize(this, 0, 1, event)" style="cursor: ns-resize; width: 100%; height: `)
+		buffer.WriteString(`<div data-onmousedown="startResize(this, 0, 1, event)" style="cursor: ns-resize; width: 100%; height: `)
 		buffer.WriteString(w)
 		buffer.WriteString(`;`)
 		writePos(left, left+1, top+1, top+2)
 
 		if rightSide {
-			buffer.WriteString(`<div onmousedown="startResize(this, 1, 1, event)" style="cursor: nwse-resize; width: `)
+			buffer.WriteString(`<div data-onmousedown="startResize(this, 1, 1, event)" style="cursor: nwse-resize; width: `)
 			buffer.WriteString(w)
 			buffer.WriteString(`; height: `)
 			buffer.WriteString(w)
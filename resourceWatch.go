@@ -0,0 +1,184 @@
+//go:build !wasm
+
+package rui
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type resourceFileStamp struct {
+	modTime time.Time
+	size    int64
+	text    bool
+}
+
+type resourceWatcher struct {
+	interval         time.Duration
+	recreateRootView bool
+	files            map[string]resourceFileStamp
+	stop             chan struct{}
+}
+
+var resourcesWatcher *resourceWatcher
+
+// WatchResources starts the development mode in which the resource directories (set by SetResourcePath
+// and the "resources" directory next to the executable file) are checked for changes every "interval"
+// (1 second if "interval" <= 0).
+//
+// When a theme or strings file is changed then all themes and strings are reloaded and the updated styles are
+// sent to all connected sessions. If "recreateRootView" is true then the root views of all sessions are
+// recreated by the SessionContent.CreateRootView function after any change including view and popup files.
+//
+// The function is intended for the development only. Use StopWatchResources to stop the watching.
+func WatchResources(interval time.Duration, recreateRootView bool) {
+	StopWatchResources()
+
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	resourcesWatcher = &resourceWatcher{
+		interval:         interval,
+		recreateRootView: recreateRootView,
+		stop:             make(chan struct{}),
+	}
+	resourcesWatcher.files = resourcesWatcher.scan()
+
+	go resourcesWatcher.run()
+}
+
+// StopWatchResources stops the resource watching started by WatchResources
+func StopWatchResources() {
+	if resourcesWatcher != nil {
+		close(resourcesWatcher.stop)
+		resourcesWatcher = nil
+	}
+}
+
+func (watcher *resourceWatcher) run() {
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-watcher.stop:
+			return
+
+		case <-ticker.C:
+			files := watcher.scan()
+			texts, views := watcher.changes(files)
+			watcher.files = files
+
+			if texts {
+				DebugLog("Resources changed. Reloading themes and strings")
+				resources.reloadTexts()
+			}
+
+			if texts || (views && watcher.recreateRootView) {
+				recreateRootView := watcher.recreateRootView
+				for _, app := range runningApps() {
+					for _, info := range app.sessionList() {
+						info.session.queueCall(func(session Session) {
+							session.(*sessionData).resourcesChanged(recreateRootView)
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+func (watcher *resourceWatcher) scan() map[string]resourceFileStamp {
+	files := map[string]resourceFileStamp{}
+	for _, path := range resources.resourceDirs() {
+		for _, dir := range []string{themeDir, stringsDir, viewDir, popupDir} {
+			text := dir == themeDir || dir == stringsDir
+			filepath.WalkDir(path+dir, func(path string, entry fs.DirEntry, err error) error {
+				if err == nil && !entry.IsDir() && strings.ToLower(filepath.Ext(path)) == ".rui" {
+					if info, err := entry.Info(); err == nil {
+						files[path] = resourceFileStamp{modTime: info.ModTime(), size: info.Size(), text: text}
+					}
+				}
+				return nil
+			})
+		}
+	}
+	return files
+}
+
+func (watcher *resourceWatcher) changes(files map[string]resourceFileStamp) (texts, views bool) {
+	changed := func(stamp resourceFileStamp) {
+		if stamp.text {
+			texts = true
+		} else {
+			views = true
+		}
+	}
+
+	for path, stamp := range files {
+		if old, ok := watcher.files[path]; !ok || old != stamp {
+			changed(stamp)
+		}
+	}
+
+	for path, stamp := range watcher.files {
+		if _, ok := files[path]; !ok {
+			changed(stamp)
+		}
+	}
+
+	return
+}
+
+func (resources *resourceManager) resourceDirs() []string {
+	result := []string{}
+	defaultPath := resources.defaultResourcePath()
+	if defaultPath != "" {
+		result = append(result, defaultPath)
+	}
+	if resources.path != "" && resources.path != defaultPath {
+		result = append(result, resources.path)
+	}
+	return result
+}
+
+// reloadTexts loads the themes and strings into a new resource manager and then replaces them in "resources",
+// so the sessions never see partially loaded resources
+func (resources *resourceManager) reloadTexts() {
+	resources.textMutex.RLock()
+	loader := &resourceManager{
+		embedFS:      resources.embedFS,
+		defaultTheme: builtinTheme(),
+		themes:       map[string]Theme{},
+		strings:      map[string]map[string]string{},
+		images:       map[string]imagePath{},
+		imageSrcSets: map[string][]scaledImage{},
+		addedThemes:  resources.addedThemes,
+		path:         resources.path,
+	}
+	resources.textMutex.RUnlock()
+
+	for _, fs := range loader.embedFS {
+		loader.scanEmbedFS(fs)
+	}
+
+	for _, path := range loader.resourceDirs() {
+		if _, err := os.Stat(path); err == nil {
+			loader.scanResourceDir(path)
+		}
+	}
+
+	for _, theme := range loader.addedThemes {
+		loader.addTheme(theme)
+	}
+
+	resources.textMutex.Lock()
+	resources.defaultTheme = loader.defaultTheme
+	resources.themes = loader.themes
+	resources.strings = loader.strings
+	resources.textMutex.Unlock()
+}
@@ -0,0 +1,71 @@
+//go:build !wasm
+
+package rui
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestReloadTexts(t *testing.T) {
+	createTestLog(t, false)
+
+	resources.textMutex.Lock()
+	resources.loadStrings("strings.rui", `strings { en = _{ hello = Hello } }`)
+	resources.textMutex.Unlock()
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for range 5 {
+			resources.reloadTexts()
+		}
+	}()
+
+	for range 100 {
+		if DefaultTheme() == nil {
+			t.Fatal("DefaultTheme() == nil")
+		}
+		GetString("hello", "en")
+	}
+	wait.Wait()
+
+	// the strings which are not loaded from the resource files are removed by the reloading
+	if text, ok := GetString("hello", "en"); ok {
+		t.Errorf(`GetString("hello", "en") = "%s" after the reloading`, text)
+	}
+
+	if value, _ := DefaultTheme().Constant("ruiButtonRadius"); value == "" {
+		t.Error("the built-in theme is not reloaded")
+	}
+}
+
+func TestReloadCustomTheme(t *testing.T) {
+	setTheme := func(theme Theme) {
+		resources.textMutex.Lock()
+		defer resources.textMutex.Unlock()
+		if resources.themes == nil {
+			resources.themes = map[string]Theme{}
+		}
+		if theme != nil {
+			resources.themes[theme.Name()] = theme
+		} else {
+			delete(resources.themes, "reloadTest")
+		}
+	}
+	defer setTheme(nil)
+
+	setTheme(NewTheme("reloadTest"))
+	session := newSession(nil, 1, "", nil).(*sessionData)
+	if !session.SetCustomTheme("reloadTest") {
+		t.Fatal("the custom theme is not set")
+	}
+
+	reloaded := NewTheme("reloadTest")
+	setTheme(reloaded)
+	session.resourcesChanged(false)
+	if session.getCustomTheme() != reloaded {
+		t.Error("the custom theme is not replaced by the reloaded one")
+	}
+}
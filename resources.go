@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const (
@@ -33,22 +34,37 @@ type imagePath struct {
 
 type resourceManager struct {
 	embedFS      []*embed.FS
+	defaultTheme Theme
 	themes       map[string]Theme
+	strings      map[string]map[string]string
 	images       map[string]imagePath
 	imageSrcSets map[string][]scaledImage
+	addedThemes  []Theme
 	path         string
+
+	// textMutex guards defaultTheme, themes and strings which are replaced by reloadTexts
+	textMutex sync.RWMutex
 }
 
 var resources = resourceManager{
 	embedFS:      []*embed.FS{},
+	defaultTheme: NewTheme(""),
 	themes:       map[string]Theme{},
+	strings:      map[string]map[string]string{},
 	images:       map[string]imagePath{},
 	imageSrcSets: map[string][]scaledImage{},
 }
 
 // AddEmbedResources adds embedded resources to the list of application resources
 func AddEmbedResources(fs *embed.FS) {
+	resources.textMutex.Lock()
+	defer resources.textMutex.Unlock()
+
 	resources.embedFS = append(resources.embedFS, fs)
+	resources.scanEmbedFS(fs)
+}
+
+func (resources *resourceManager) scanEmbedFS(fs *embed.FS) {
 	rootDirs := resources.embedRootDirs(fs)
 	for _, dir := range rootDirs {
 		switch dir {
@@ -109,7 +125,7 @@ func (resources *resourceManager) scanEmbedThemesDir(fs *embed.FS, dir string) {
 				resources.scanEmbedThemesDir(fs, path)
 			} else if strings.ToLower(filepath.Ext(name)) == ".rui" {
 				if data, err := fs.ReadFile(path); err == nil {
-					resources.registerThemeText(string(data))
+					resources.registerThemeText(path, string(data))
 				}
 			}
 		}
@@ -200,7 +216,7 @@ func (resources *resourceManager) scanThemesDir(path string) {
 					resources.scanThemesDir(newPath)
 				} else if strings.ToLower(filepath.Ext(newPath)) == ".rui" {
 					if data, err := os.ReadFile(newPath); err == nil {
-						resources.registerThemeText(string(data))
+						resources.registerThemeText(newPath, string(data))
 					} else {
 						ErrorLog(err.Error())
 					}
@@ -220,36 +236,38 @@ func SetResourcePath(path string) {
 		resources.path += "/"
 	}
 
-	resources.scanImagesDirectory(resources.path+imageDir, "")
-	resources.scanThemesDir(resources.path + themeDir)
-	resources.scanStringsDir(resources.path + stringsDir)
+	resources.textMutex.Lock()
+	defer resources.textMutex.Unlock()
+	resources.scanResourceDir(resources.path)
 }
 
-func (resources *resourceManager) scanDefaultResourcePath() {
+func (resources *resourceManager) scanResourceDir(path string) {
+	resources.scanImagesDirectory(path+imageDir, "")
+	resources.scanThemesDir(path + themeDir)
+	resources.scanStringsDir(path + stringsDir)
+}
+
+func (resources *resourceManager) defaultResourcePath() string {
 	if exe, err := os.Executable(); err == nil {
-		path := filepath.Dir(exe) + "/resources/"
-		resources.scanImagesDirectory(path+imageDir, "")
-		resources.scanThemesDir(path + themeDir)
-		resources.scanStringsDir(path + stringsDir)
+		return filepath.Dir(exe) + "/resources/"
 	}
+	return ""
 }
 
-func (resources *resourceManager) registerThemeText(text string) bool {
-	theme, ok := CreateThemeFromText(text)
-	if !ok {
-		return false
+func (resources *resourceManager) scanDefaultResourcePath() {
+	if path := resources.defaultResourcePath(); path != "" {
+		resources.textMutex.Lock()
+		defer resources.textMutex.Unlock()
+		resources.scanResourceDir(path)
 	}
+}
 
-	name := theme.Name()
-	if name == "" {
-		defaultTheme.Append(theme)
-	} else if t, ok := resources.themes[name]; ok {
-		t.Append(theme)
-	} else {
-		resources.themes[name] = theme
+func (resources *resourceManager) registerThemeText(filename, text string) bool {
+	theme, ok := CreateThemeFromFile(filename, text)
+	if ok {
+		resources.addTheme(theme)
 	}
-
-	return true
+	return ok
 }
 
 func serveResourceFile(filename string, w http.ResponseWriter, r *http.Request) bool {
@@ -462,13 +480,38 @@ func AllImageResources() []string {
 // AddTheme adds theme to application
 func AddTheme(theme Theme) {
 	if theme != nil {
-		name := theme.Name()
-		if name == "" {
-			defaultTheme.Append(theme)
-		} else if t, ok := resources.themes[name]; ok {
-			t.Append(theme)
-		} else {
-			resources.themes[name] = theme
-		}
+		resources.textMutex.Lock()
+		defer resources.textMutex.Unlock()
+
+		resources.addedThemes = append(resources.addedThemes, theme)
+		resources.addTheme(theme)
+	}
+}
+
+func (resources *resourceManager) addTheme(theme Theme) {
+	name := theme.Name()
+	if name == "" {
+		resources.defaultTheme.Append(theme)
+	} else if t, ok := resources.themes[name]; ok {
+		t.Append(theme)
+	} else {
+		resources.themes[name] = theme
 	}
 }
+
+func (resources *resourceManager) theme(name string) (Theme, bool) {
+	resources.textMutex.RLock()
+	defer resources.textMutex.RUnlock()
+
+	theme, ok := resources.themes[name]
+	return theme, ok
+}
+
+// DefaultTheme returns the default theme of the application: the built-in theme
+// extended by all unnamed themes from the resources and added by AddTheme function
+func DefaultTheme() Theme {
+	resources.textMutex.RLock()
+	defer resources.textMutex.RUnlock()
+
+	return resources.defaultTheme
+}
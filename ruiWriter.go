@@ -1,12 +1,14 @@
 package rui
 
-/*
 import (
 	"fmt"
 	"strconv"
 	"strings"
 )
 
+// ruiWriter writes the text in the canonical format of .rui files: one property per line, the tab indentation,
+// " = " between a tag and a value, a comma after each property and array element, and short arrays of text values
+// in one line
 type ruiWriter interface {
 	startObject(tag string)
 	startObjectProperty(tag, objectTag string)
@@ -14,6 +16,9 @@ type ruiWriter interface {
 	startArrayProperty(tag string)
 	endObArray()
 	writeProperty(tag string, value any)
+	writeArrayElement(value any)
+	writeComments(comments []string)
+	writeTrailingComment(comment string)
 	finish() string
 }
 
@@ -21,106 +26,165 @@ type ruiStringer interface {
 	ruiString(writer ruiWriter)
 }
 
+// ruiWriterLevel describes an object or an array which is being written
+type ruiWriterLevel struct {
+	items    int
+	comments bool
+}
+
 type ruiWriterData struct {
 	buffer *strings.Builder
 	indent string
+	levels []ruiWriterLevel
+
+	// lineEnd - if true then the line is finished and waits for a trailing comment
+	lineEnd bool
+	// trailing - if true then a trailing comment is written in the current line
+	trailing bool
 }
 
+// maxInlineArrayLength is the maximal length of an array of text values written in one line
+const maxInlineArrayLength = 100
+
 func newRUIWriter() ruiWriter {
 	writer := new(ruiWriterData)
+	writer.levels = []ruiWriterLevel{{}}
 	return writer
 }
 
-func (writer *ruiWriterData) writeIndent() {
+func (writer *ruiWriterData) endLine() {
 	if writer.buffer == nil {
 		writer.buffer = allocStringBuilder()
-		writer.indent = ""
-		return
 	}
 
+	if writer.lineEnd {
+		writer.buffer.WriteRune('\n')
+		writer.lineEnd = false
+		writer.trailing = false
+	}
+}
+
+func (writer *ruiWriterData) writeIndent() {
+	writer.endLine()
 	if writer.indent != "" {
 		writer.buffer.WriteString(writer.indent)
 	}
 }
 
-func (writer *ruiWriterData) writeString(str string) {
-	hasEsc := strings.ContainsAny(str, "\t\"\r\n")
-	if hasEsc || strings.ContainsAny(str, " ,;'`[]{}()") {
-		if !strings.Contains(str, "`") && (hasEsc || strings.Contains(str, `\`)) {
-			writer.buffer.WriteRune('`')
-			writer.buffer.WriteString(str)
-			writer.buffer.WriteRune('`')
-		} else {
-			replace := []struct{ old, new string }{
-				{old: `\`, new: `\\`},
-				{old: "\t", new: `\t`},
-				{old: "\r", new: `\r`},
-				{old: "\n", new: `\n`},
-				{old: "\"", new: `\"`},
-			}
-			for _, s := range replace {
-				str = strings.ReplaceAll(str, s.old, s.new)
-			}
-			writer.buffer.WriteRune('"')
-			writer.buffer.WriteString(str)
-			writer.buffer.WriteRune('"')
-		}
+func (writer *ruiWriterData) level() *ruiWriterLevel {
+	return &writer.levels[len(writer.levels)-1]
+}
+
+// startItem starts a new property or an array element
+func (writer *ruiWriterData) startItem() {
+	writer.level().items++
+	writer.writeIndent()
+}
+
+// finishItem finishes a property or an array element. The root object is finished without a comma
+func (writer *ruiWriterData) finishItem() {
+	if len(writer.levels) > 1 {
+		writer.buffer.WriteRune(',')
+	}
+	writer.lineEnd = true
+}
+
+func (writer *ruiWriterData) push() {
+	writer.levels = append(writer.levels, ruiWriterLevel{})
+	writer.indent += "\t"
+	writer.lineEnd = true
+}
+
+// pop closes the object or the array. An empty one is closed in the same line ("{}" or "[]")
+func (writer *ruiWriterData) pop(close rune) {
+	level := writer.level()
+	writer.levels = writer.levels[:len(writer.levels)-1]
+	if len(writer.indent) > 0 {
+		writer.indent = writer.indent[1:]
+	}
+
+	if level.items == 0 && !level.comments && writer.lineEnd && !writer.trailing {
+		writer.lineEnd = false
 	} else {
+		writer.writeIndent()
+	}
+	writer.buffer.WriteRune(close)
+}
+
+func (writer *ruiWriterData) writeString(str string) {
+	switch {
+	case str == "":
+		writer.buffer.WriteString(`""`)
+
+	case !isQuotesNeeded(str):
 		writer.buffer.WriteString(str)
+
+	case strings.ContainsAny(str, "\n\\") && !strings.Contains(str, "`"):
+		writer.buffer.WriteRune('`')
+		writer.buffer.WriteString(str)
+		writer.buffer.WriteRune('`')
+
+	default:
+		writer.buffer.WriteRune('"')
+		writer.buffer.WriteString(replaceEscapeSymbols(str))
+		writer.buffer.WriteRune('"')
+	}
+}
+
+func (writer *ruiWriterData) writeObjectTag(tag string) {
+	if tag == "_" || tag == "" {
+		writer.buffer.WriteString("_{")
+		return
+	}
+
+	if isQuotesNeededForObjectName(tag) {
+		writer.buffer.WriteRune('"')
+		writer.buffer.WriteString(replaceEscapeSymbols(tag))
+		writer.buffer.WriteRune('"')
+	} else {
+		writer.buffer.WriteString(tag)
 	}
+	writer.buffer.WriteString(" {")
 }
 
 func (writer *ruiWriterData) startObject(tag string) {
-	writer.writeIndent()
-	writer.indent += "\t"
-	writer.writeString(tag)
-	writer.buffer.WriteString(" {\n")
+	writer.startItem()
+	writer.writeObjectTag(tag)
+	writer.push()
 }
 
 func (writer *ruiWriterData) startObjectProperty(tag, objectTag string) {
-	writer.writeIndent()
-	writer.indent += "\t"
+	writer.startItem()
 	writer.writeString(tag)
-	writer.writeString(" = ")
-	if objectTag == "" {
-		objectTag = "_"
-	}
-	writer.writeString(objectTag)
-	writer.buffer.WriteString(" {\n")
+	writer.buffer.WriteString(" = ")
+	writer.writeObjectTag(objectTag)
+	writer.push()
 }
 
 func (writer *ruiWriterData) endObject() {
-	if len(writer.indent) > 0 {
-		writer.indent = writer.indent[1:]
-	}
-	writer.writeIndent()
-	writer.buffer.WriteRune('}')
+	writer.pop('}')
+	writer.finishItem()
 }
 
 func (writer *ruiWriterData) startArrayProperty(tag string) {
-	writer.writeIndent()
+	writer.startItem()
 	writer.writeString(tag)
-	writer.buffer.WriteString(" = [\n")
-	writer.indent += "\t"
+	writer.buffer.WriteString(" = [")
+	writer.push()
 }
 
 func (writer *ruiWriterData) endObArray() {
-	if len(writer.indent) > 0 {
-		writer.indent = writer.indent[1:]
-	}
-	writer.writeIndent()
-	writer.buffer.WriteString("],\n")
+	writer.pop(']')
+	writer.finishItem()
 }
 
 func (writer *ruiWriterData) writeValue(value any) {
-
 	switch value := value.(type) {
 	case string:
 		writer.writeString(value)
 
 	case ruiStringer:
 		value.ruiString(writer)
-		// TODO
 
 	case fmt.Stringer:
 		writer.writeString(value.String())
@@ -132,93 +196,111 @@ func (writer *ruiWriterData) writeValue(value any) {
 		writer.writeString(fmt.Sprintf("%g", value))
 
 	case []string:
-		switch len(value) {
-		case 0:
-			writer.buffer.WriteString("[]\n")
-
-		case 1:
-			writer.writeString(value[0])
-
-		default:
-			writer.buffer.WriteString("[\n")
-			writer.indent += "\t"
-			for _, v := range value {
-				writer.buffer.WriteString(writer.indent)
-				writer.writeString(v)
-				writer.buffer.WriteString(",\n")
-			}
-
-			writer.indent = writer.indent[1:]
-			writer.buffer.WriteString(writer.indent)
-			writer.buffer.WriteRune(']')
+		if len(value) == 0 {
+			writer.buffer.WriteString("[]")
+			return
 		}
 
-	case []View:
-		switch len(value) {
-		case 0:
-			writer.buffer.WriteString("[]\n")
-
-		case 1:
-			writer.writeValue(value[0])
-
-		default:
-			writer.buffer.WriteString("[\n")
-			writer.indent += "\t"
-			for _, v := range value {
-				writer.buffer.WriteString(writer.indent)
-				v.ruiString(writer)
-				writer.buffer.WriteString(",\n")
-			}
+		buffer := writer.buffer
+		inline := allocStringBuilder()
+		defer freeStringBuilder(inline)
 
-			writer.indent = writer.indent[1:]
-			writer.buffer.WriteString(writer.indent)
-			writer.buffer.WriteRune(']')
+		writer.buffer = inline
+		inline.WriteRune('[')
+		for i, v := range value {
+			if i > 0 {
+				inline.WriteString(", ")
+			}
+			writer.writeString(v)
 		}
+		inline.WriteRune(']')
+		writer.buffer = buffer
 
-	case []any:
-		switch len(value) {
-		case 0:
-			writer.buffer.WriteString("[]\n")
-
-		case 1:
-			writer.writeValue(value[0])
-
-		default:
-			writer.buffer.WriteString("[\n")
-			writer.indent += "\t"
-			for _, v := range value {
-				writer.buffer.WriteString(writer.indent)
-				writer.writeValue(v)
-				writer.buffer.WriteString(",\n")
-			}
+		if inline.Len()+len(writer.indent)*4 <= maxInlineArrayLength && !strings.ContainsRune(inline.String(), '\n') {
+			buffer.WriteString(inline.String())
+			return
+		}
 
-			writer.indent = writer.indent[1:]
-			writer.buffer.WriteString(writer.indent)
-			writer.buffer.WriteRune(']')
+		buffer.WriteRune('[')
+		writer.push()
+		for _, v := range value {
+			writer.writeArrayElement(v)
 		}
+		writer.pop(']')
 
 	default:
 		if n, ok := isInt(value); ok {
 			writer.buffer.WriteString(strconv.Itoa(n))
 		}
 	}
-	writer.buffer.WriteString(",\n")
 }
 
 func (writer *ruiWriterData) writeProperty(tag string, value any) {
-	writer.writeIndent()
+	writer.startItem()
 	writer.writeString(tag)
 	writer.buffer.WriteString(" = ")
 	writer.writeValue(value)
+	writer.finishItem()
+}
+
+func (writer *ruiWriterData) writeArrayElement(value any) {
+	writer.startItem()
+	writer.writeValue(value)
+	writer.finishItem()
+}
+
+// writeComments writes the comment lines before the next property or array element.
+// An empty string means an empty line, the empty lines before the first property are skipped
+func (writer *ruiWriterData) writeComments(comments []string) {
+	if len(comments) == 0 {
+		return
+	}
+
+	level := writer.level()
+	if comments[0] == "" && level.items > 0 {
+		writer.endLine()
+		writer.buffer.WriteRune('\n')
+	}
+
+	start := 0
+	for start < len(comments) && comments[start] == "" {
+		start++
+	}
+
+	end := len(comments)
+	for end > start && comments[end-1] == "" {
+		end--
+	}
+
+	for _, comment := range comments[start:end] {
+		level.comments = true
+		if comment == "" {
+			writer.endLine()
+			writer.buffer.WriteRune('\n')
+		} else {
+			writer.writeIndent()
+			writer.buffer.WriteString(comment)
+			writer.lineEnd = true
+		}
+	}
+}
+
+// writeTrailingComment writes the comment at the end of the line of the last property, array element, '{' or '['
+func (writer *ruiWriterData) writeTrailingComment(comment string) {
+	if comment != "" && writer.buffer != nil {
+		writer.buffer.WriteRune(' ')
+		writer.buffer.WriteString(comment)
+		writer.trailing = true
+	}
 }
 
 func (writer *ruiWriterData) finish() string {
 	result := ""
 	if writer.buffer != nil {
+		writer.endLine()
 		result = writer.buffer.String()
 		freeStringBuilder(writer.buffer)
 		writer.buffer = nil
 	}
 	return result
 }
-*/
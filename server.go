@@ -0,0 +1,97 @@
+//go:build !wasm
+
+package rui
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Server is the HTTP handler which serves the rui application together with custom routes.
+// The application is mounted under the URL prefix of the server, other routes are added by
+// the Handle and HandleFunc methods, and all requests pass through the middleware chain (see Use).
+//
+// Example:
+//
+//	server := rui.NewServer("/ui", createSessionContent, rui.AppParams{
+//		Title: "Awesome app",
+//	})
+//	server.HandleFunc("GET /api/status", statusHandler)
+//	server.Use(loggingMiddleware, authMiddleware)
+//
+//	if err := server.ListenAndServe(ctx, "localhost:8000"); err != nil {
+//		log.Println(err)
+//	}
+//
+// Server can also be used as the http.Handler of another server or web framework.
+type Server struct {
+	app        *application
+	mux        *http.ServeMux
+	middleware []func(http.Handler) http.Handler
+	handler    http.Handler
+}
+
+// NewServer creates the server of the new rui application which is available under the URL prefix,
+// e.g. "/ui" (the start page is "/ui/"). If the prefix is empty then the application is mounted at the root.
+// The requests of all HTTP methods under the prefix are handled by the application
+func NewServer(urlPrefix string, createContentFunc func(Session) SessionContent, params AppParams) *Server {
+	resources.scanDefaultResourcePath()
+
+	server := &Server{
+		app: newApplication(createContentFunc, params),
+		mux: http.NewServeMux(),
+	}
+	server.handler = server.mux
+
+	if prefix := strings.Trim(urlPrefix, "/"); prefix != "" {
+		// ServeMux redirects "/prefix" to "/prefix/", so the relative URLs of the start page are resolved correctly
+		server.mux.Handle("/"+prefix+"/", &httpHandler{app: server.app, prefix: "/" + prefix})
+	} else {
+		server.mux.Handle("/", server.app)
+	}
+
+	return server
+}
+
+// Handle registers the handler for the pattern (see http.ServeMux for the pattern syntax)
+func (server *Server) Handle(pattern string, handler http.Handler) {
+	server.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers the handler function for the pattern (see http.ServeMux for the pattern syntax)
+func (server *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	server.mux.HandleFunc(pattern, handler)
+}
+
+// Use appends the middleware to the chain which handles all requests of the server. The first added
+// middleware is the outermost one. Use must be called before the server starts handling requests.
+//
+// The WebSocket connection of the application requires the http.ResponseWriter which implements http.Hijacker,
+// so a middleware which wraps the writer (e.g. gzip compression) must skip the requests to the "ws" path
+// or pass the Hijack method through
+func (server *Server) Use(middleware ...func(http.Handler) http.Handler) {
+	server.middleware = append(server.middleware, middleware...)
+
+	server.handler = server.mux
+	for i := len(server.middleware) - 1; i >= 0; i-- {
+		server.handler = server.middleware[i](server.handler)
+	}
+}
+
+// ServeHTTP handles the request by the middleware chain and the routes of the server
+func (server *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	server.handler.ServeHTTP(w, req)
+}
+
+// Application returns the rui application of the server
+func (server *Server) Application() Application {
+	return server.app
+}
+
+// ListenAndServe runs the server on the address until the context is canceled or FinishApp is called.
+// The TLS, the redirect from port 80, and the shutdown are configured by the AppParams of the server
+// in the same way as StartAppContext does
+func (server *Server) ListenAndServe(ctx context.Context, addr string) error {
+	return server.app.listenAndServe(ctx, addr, server)
+}
@@ -0,0 +1,54 @@
+package rui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	server := NewServer("/ui/", func(Session) SessionContent {
+		return testContent{}
+	}, AppParams{})
+	defer server.app.removeFromApps()
+
+	server.HandleFunc("GET /api/status", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	server.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Test", "1")
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	for _, test := range []struct {
+		method, path string
+		status       int
+		body         string
+	}{
+		{http.MethodGet, "/api/status", http.StatusOK, "ok"},
+		{http.MethodGet, "/ui", http.StatusTemporaryRedirect, ""},
+		{http.MethodGet, "/ui/", http.StatusOK, `<script src="script.js"`},
+		{http.MethodGet, "/ui/script.js", http.StatusOK, "function sendMessage"},
+		{http.MethodPost, "/ui/", http.StatusOK, `[["reloadPage"]]`},
+	} {
+		body := ""
+		if test.method == http.MethodPost {
+			body = "nop{session=1}"
+		}
+
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, strings.NewReader(body)))
+		if recorder.Code != test.status {
+			t.Errorf("%s %s: status = %d, expected %d", test.method, test.path, recorder.Code, test.status)
+		}
+		if !strings.Contains(recorder.Body.String(), test.body) {
+			t.Errorf("%s %s: the response does not contain %q", test.method, test.path, test.body)
+		}
+		if recorder.Header().Get("X-Test") != "1" {
+			t.Errorf("%s %s: the middleware is not applied", test.method, test.path)
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package rui
 
 import (
+	"context"
 	"fmt"
 	"iter"
+	"log/slog"
 	"maps"
 	"net/url"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type bridge interface {
+	startBatch()
 	writeScript(script string)
 	startUpdateScript(htmlID string) bool
 	finishUpdateScript(htmlID string)
@@ -22,6 +27,7 @@ type bridge interface {
 	updateProperty(htmlID, property string, value any)
 	removeProperty(htmlID, property string)
 	sendResponse()
+	finishBatch()
 	setAnimationCSS(css string)
 	appendAnimationCSS(css string)
 	canvasStart(htmlID string)
@@ -85,6 +91,24 @@ type Session interface {
 	// RemoteAddr returns the client address.
 	RemoteAddr() string
 
+	// User returns the user authenticated by AppParams.Authenticate (nil if the hook is not set)
+	User() Principal
+
+	// ClientID returns the identifier of the browser of the client which is kept by the cookie
+	// (see AppParams.ClientIDCookie). All sessions (browser tabs) of the browser have the same identifier.
+	// Returns "" if the identifier is not used or the browser does not accept cookies
+	ClientID() string
+
+	// ConnectionState returns the state of the connection with the client: SessionConnecting, SessionConnected,
+	// SessionDisconnected, or SessionClosed. Use SessionConnectionListener to track the changes
+	ConnectionState() int
+
+	// Logger returns the logger (see SetLogger) with the "session" and "remote" attributes of the session
+	Logger() *slog.Logger
+
+	// LastError returns the text of the last error of the session
+	LastError() string
+
 	// Language returns the current session language
 	Language() string
 
@@ -94,9 +118,30 @@ type Session interface {
 	// GetString returns the text for the current language
 	GetString(tag string) (string, bool)
 
+	// FormatString returns the text for the current language formatted with parameters (see FormatMessage).
+	// For example, for the string resource
+	//
+	//	files = "{count, plural, =0 {No files} one {# file} other {# files}}"
+	//
+	// session.FormatString("files", map[string]any{"count": 3}) returns "3 files"
+	FormatString(tag string, params map[string]any) string
+
+	// Announce reads the text (translated to the current language) by screen readers using the hidden live region.
+	// If "assertive" is true then the current speech is interrupted, otherwise the text is read when the user is idle.
+	Announce(text string, assertive bool)
+
+	// Print opens the print dialog of the browser for the view and its children only.
+	// If the view is nil then the whole page is printed. Popups and tooltips are never printed.
+	// The page size and margins are set by the "ruiPrintPageSize" and "ruiPrintPageMargin" theme constants
+	Print(view View)
+
+	// Formatter returns the formatter of numbers, dates and times for the language chain of the session
+	Formatter() LocaleFormatter
+
 	// Content returns the SessionContent of session
 	Content() SessionContent
 	setContent(content SessionContent) bool
+	resourcesChanged(recreateRootView bool)
 
 	// SetTitle sets the text of the browser title/tab
 	SetTitle(title string)
@@ -137,6 +182,26 @@ type Session interface {
 	// OpenURL opens the url in the new browser tab
 	OpenURL(url string)
 
+	// RunScript executes the JavaScript code on the client side. The library does not send scripts to the client,
+	// so the method is disabled unless AppParams.AllowScripts is true. The scripts are executed by eval,
+	// so the Content-Security-Policy of the page must contain 'unsafe-eval'
+	RunScript(script string)
+
+	// BroadcastToClient calls the function for all sessions of the client of the session (see ClientID),
+	// including the current one. The function is called in the goroutine of each session, the sessions
+	// which are disconnected call it after the reconnection.
+	// The other sessions are found by the client identifier only, so they are reached only if
+	// AppParams.ClientIDCookie is set and the browser accepts cookies. Otherwise the function
+	// is called for the current session only
+	BroadcastToClient(fn func(Session))
+
+	// Logout removes the user (see User) from all sessions of the client (see BroadcastToClient) and reloads
+	// their pages, so the client is authenticated again by AppParams.Authenticate.
+	// The credentials of the user (e.g. the cookie) must be invalidated by the application before the call.
+	// If AppParams.ClientIDCookie is not set then only the current session is logged out, the other tabs
+	// keep the user until they are reloaded
+	Logout()
+
 	// ClientStorage returns an interface for accessing client-side key-value storage.
 	ClientStorage() ClientStorage
 
@@ -144,6 +209,17 @@ type Session interface {
 	// Invoke SetHotKey(..., ..., nil) for remove hotkey function.
 	SetHotKey(keyCode KeyCode, controlKeys ControlKeyMask, fn func(Session))
 
+	// FocusView sets the keyboard focus on the view
+	FocusView(view View)
+
+	// FocusNext moves the keyboard focus to the next focusable view (like the Tab key).
+	// If a popup is shown then the focus is moved within the top popup only.
+	FocusNext()
+
+	// FocusPrevious moves the keyboard focus to the previous focusable view (like the Shift+Tab keys).
+	// If a popup is shown then the focus is moved within the top popup only.
+	FocusPrevious()
+
 	// StartTimer starts a timer on the client side.
 	// The first argument specifies the timer period in milliseconds.
 	// The second argument specifies a function that will be called on each timer event.
@@ -154,6 +230,12 @@ type Session interface {
 	StopTimer(timerID int)
 
 	getCurrentTheme() Theme
+	getCustomTheme() Theme
+	setCustomTheme(theme Theme)
+	updateCustomTheme(theme Theme)
+	setInspectorPick(fn func(View))
+	setUser(user Principal)
+	setClientID(clientID string)
 	registerAnimation(props []AnimatedProperty) string
 
 	getColor(tag string, darkMode bool) (Color, bool)
@@ -195,22 +277,35 @@ type Session interface {
 	canvasTextMetrics(htmlID, font, text string) TextMetrics
 
 	addToEventsQueue(data DataObject)
+	queueCall(fn func(Session))
 	handleAnswer(command string, data DataObject) bool
 	handleRootSize(data DataObject)
 	handleResize(data DataObject)
 	handleEvent(command string, data DataObject)
 	close()
+	shutdown(ctx context.Context)
+	errorLog(text string, args ...any)
+	setLastError(text string)
 
 	onStart()
 	onFinish()
+	onShutdown()
 	onPause()
 	onResume()
 	onDisconnect()
 	onReconnect()
+	onClientSessionStart(otherSessionID int)
+	onClientSessionFinish(otherSessionID int)
+	setConnectionState(state int)
 
 	ignoreViewUpdates() bool
 	setIgnoreViewUpdates(ignore bool)
 
+	startValidation()
+	finishValidation() []*DataError
+	isValidation() bool
+	addValidationError(position DataPosition, tag, message string)
+
 	popupManager() *popupManager
 	imageManager() *imageManager
 }
@@ -251,6 +346,18 @@ type sessionData struct {
 	pauseTime        int64
 	popupDefaults    Params
 	clientStorage    ClientStorage
+	validationErrors []*DataError
+	inspectorPick    func(View)
+	user             Principal
+	clientID         string
+	lastError        string
+	lastErrorMutex   sync.Mutex
+	eventsMutex      sync.RWMutex
+	eventsDone       chan struct{}
+	bridgeMutex      sync.Mutex
+	calls            []func(Session)
+	callsMutex       sync.Mutex
+	connectionState  atomic.Int32
 }
 
 func newSession(app Application, id int, customTheme string, params DataObject) Session {
@@ -294,17 +401,72 @@ func (session *sessionData) ID() int {
 }
 
 func (session *sessionData) setBridge(events chan DataObject, bridge bridge) {
+	session.bridgeMutex.Lock()
+	defer session.bridgeMutex.Unlock()
+
+	// wakes up the goroutines which wait in postEvent for the free space in the old queue of events,
+	// otherwise they would hold eventsMutex
+	if session.eventsDone != nil {
+		close(session.eventsDone)
+	}
+
+	session.eventsMutex.Lock()
 	if session.events != nil {
 		close(session.events)
 	}
 	session.events = events
+	session.eventsDone = nil
+	if events != nil {
+		session.eventsDone = make(chan struct{})
+	}
+	session.eventsMutex.Unlock()
 	session.bridge = bridge
 }
 
 func (session *sessionData) close() {
-	if session.events != nil {
-		obj, _ := ParseDataText(`session-close{session="` + strconv.Itoa(session.sessionID) + `"}`)
-		session.events <- obj
+	obj, _ := ParseDataText(`session-close{session="` + strconv.Itoa(session.sessionID) + `"}`)
+	session.postEvent(context.Background(), obj)
+}
+
+// postEvent sends the event to the goroutine of the session. If the queue of events is full then
+// the function waits until the context is done or the queue is replaced by setBridge.
+// Returns false if the event is not sent
+func (session *sessionData) postEvent(ctx context.Context, event DataObject) bool {
+	session.eventsMutex.RLock()
+	defer session.eventsMutex.RUnlock()
+
+	if session.events == nil {
+		return false
+	}
+
+	select {
+	case session.events <- event:
+		return true
+
+	case <-session.eventsDone:
+		return false
+
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// shutdown notifies the session and its client that the server is going away and finishes the session.
+// The notification is dropped if the queue of events is not freed before the context is done
+func (session *sessionData) shutdown(ctx context.Context) {
+	session.eventsMutex.RLock()
+	connected := session.events != nil
+	session.eventsMutex.RUnlock()
+
+	if connected {
+		if session.postEvent(ctx, NewDataObject("server-shutdown")) {
+			obj, _ := ParseDataText(`session-close{session="` + strconv.Itoa(session.sessionID) + `"}`)
+			session.postEvent(ctx, obj)
+		}
+	} else {
+		session.onShutdown()
+		session.onFinish()
+		session.app.removeSession(session.sessionID)
 	}
 }
 
@@ -360,20 +522,12 @@ func (session *sessionData) writeInitScript() {
 		return
 	}
 
-	if ProtocolInDebugLog {
-		DebugLog("Start session:")
+	if protocolLogEnabled() {
+		protocolLog("Start session:")
 	}
 
 	if css := session.getCurrentTheme().cssText(session); css != "" {
-		css = strings.ReplaceAll(css, "\n", `\n`)
-		css = strings.ReplaceAll(css, "\t", `\t`)
-
-		script := `document.querySelector('style').textContent += "` + css + "\";\n"
-		session.bridge.writeScript(script)
-
-		if ProtocolInDebugLog {
-			DebugLog(script)
-		}
+		session.bridge.callFunc("appendStyles", unescapeScriptCSS(css))
 	}
 
 	if session.rootView != nil {
@@ -384,12 +538,13 @@ func (session *sessionData) writeInitScript() {
 		html := buffer.String()
 		session.bridge.callFunc("updateInnerHTML", "ruiRootView", html)
 
-		if ProtocolInDebugLog {
-			DebugLog(html)
+		if protocolLogEnabled() {
+			protocolLog(html)
 		}
 	}
 
 	session.updateTooltipConstants()
+	session.updateConnectionLostConstants()
 }
 
 func (session *sessionData) updateTooltipConstants() {
@@ -406,9 +561,7 @@ func (session *sessionData) updateTooltipConstants() {
 }
 
 func (session *sessionData) reload() {
-
-	css := appStyles + session.getCurrentTheme().cssText(session) + session.animationCSS
-	session.bridge.callFunc("setStyles", css)
+	session.updateStyles()
 
 	if session.rootView != nil {
 		buffer := allocStringBuilder()
@@ -418,8 +571,55 @@ func (session *sessionData) reload() {
 		session.bridge.updateInnerHTML("ruiRootView", buffer.String())
 		session.bridge.callFunc("scanElementsSize")
 	}
+}
+
+// updateStyles sends to the client the CSS and the CSS variables generated by the current theme
+func (session *sessionData) updateStyles() {
+	css := appStyles + unescapeScriptCSS(session.getCurrentTheme().cssText(session)+session.animationCSS)
+	session.bridge.callFunc("setStyles", css)
 
 	session.updateTooltipConstants()
+	session.updateConnectionLostConstants()
+}
+
+// updateConnectionLostConstants sends the colors and the text of the overlay which is shown while the connection is lost
+func (session *sessionData) updateConnectionLostConstants() {
+	if color, ok := session.Color("ruiConnectionLostBackground"); ok {
+		session.bridge.callFunc("setCssVar", "--connection-lost-background", color.cssString())
+	}
+	if color, ok := session.Color("ruiConnectionLostTextColor"); ok {
+		session.bridge.callFunc("setCssVar", "--connection-lost-text-color", color.cssString())
+	}
+
+	message := ""
+	if session.app != nil {
+		message, _ = session.GetString(session.app.Params().ConnectionLostMessage)
+	}
+	if message == "" {
+		message, _ = session.GetString(DefaultConnectionLostMessage)
+	}
+	session.bridge.callFunc("setConnectionLostText", message)
+}
+
+func (session *sessionData) resourcesChanged(recreateRootView bool) {
+	session.currentTheme = nil
+	if session.customTheme != nil {
+		// the custom theme set by SetCustomTheme is replaced by its reloaded version
+		if theme, ok := resources.theme(session.customTheme.Name()); ok {
+			session.customTheme = theme
+		}
+	}
+
+	if recreateRootView && session.content != nil {
+		if view := session.content.CreateRootView(session); view != nil {
+			view.setParentID("ruiRootView")
+			session.rootView = view
+		}
+	}
+
+	if session.bridge != nil {
+		session.reload()
+	}
 }
 
 func (session *sessionData) ignoreViewUpdates() bool {
@@ -475,7 +675,7 @@ func (session *sessionData) callFunc(funcName string, args ...any) {
 	if session.bridge != nil {
 		session.bridge.callFunc(funcName, args...)
 	} else {
-		ErrorLog("No connection")
+		session.errorLog("No connection")
 	}
 }
 
@@ -484,7 +684,7 @@ func (session *sessionData) updateInnerHTML(htmlID, html string) {
 		if session.bridge != nil {
 			session.bridge.updateInnerHTML(htmlID, html)
 		} else {
-			ErrorLog("No connection")
+			session.errorLog("No connection")
 		}
 	}
 }
@@ -494,7 +694,7 @@ func (session *sessionData) appendToInnerHTML(htmlID, html string) {
 		if session.bridge != nil {
 			session.bridge.appendToInnerHTML(htmlID, html)
 		} else {
-			ErrorLog("No connection")
+			session.errorLog("No connection")
 		}
 	}
 }
@@ -504,7 +704,7 @@ func (session *sessionData) updateCSSProperty(htmlID, property, value string) {
 		if session.bridge != nil {
 			session.bridge.updateCSSProperty(htmlID, property, value)
 		} else {
-			ErrorLog("No connection")
+			session.errorLog("No connection")
 		}
 	}
 }
@@ -514,7 +714,7 @@ func (session *sessionData) updateProperty(htmlID, property string, value any) {
 		if session.bridge != nil {
 			session.bridge.updateProperty(htmlID, property, value)
 		} else {
-			ErrorLog("No connection")
+			session.errorLog("No connection")
 		}
 	}
 }
@@ -524,7 +724,7 @@ func (session *sessionData) removeProperty(htmlID, property string) {
 		if session.bridge != nil {
 			session.bridge.removeProperty(htmlID, property)
 		} else {
-			ErrorLog("No connection")
+			session.errorLog("No connection")
 		}
 	}
 }
@@ -533,7 +733,7 @@ func (session *sessionData) writeScript(script string) {
 	if session.bridge != nil {
 		session.bridge.writeScript(script)
 	} else {
-		ErrorLog("No connection")
+		session.errorLog("No connection")
 	}
 }
 
@@ -541,7 +741,7 @@ func (session *sessionData) startUpdateScript(htmlID string) bool {
 	if session.bridge != nil {
 		return session.bridge.startUpdateScript(htmlID)
 	}
-	ErrorLog("No connection")
+	session.errorLog("No connection")
 	return false
 }
 
@@ -549,7 +749,7 @@ func (session *sessionData) finishUpdateScript(htmlID string) {
 	if session.bridge != nil {
 		session.bridge.finishUpdateScript(htmlID)
 	} else {
-		ErrorLog("No connection")
+		session.errorLog("No connection")
 	}
 }
 
@@ -557,7 +757,7 @@ func (session *sessionData) sendResponse() {
 	if session.bridge != nil {
 		session.bridge.sendResponse()
 	} else {
-		ErrorLog("No connection")
+		session.errorLog("No connection")
 	}
 }
 
@@ -566,7 +766,7 @@ func (session *sessionData) addAnimationCSS(css string) {
 	if session.bridge != nil {
 		session.bridge.appendAnimationCSS(css)
 	} else {
-		ErrorLog("No connection")
+		session.errorLog("No connection")
 	}
 }
 
@@ -604,7 +804,7 @@ func (session *sessionData) removeAnimation(keyframe string) {
 		if session.bridge != nil {
 			session.bridge.setAnimationCSS(session.animationCSS)
 		} else {
-			ErrorLog("No connection")
+			session.errorLog("No connection")
 		}
 	}
 }
@@ -615,7 +815,7 @@ func (session *sessionData) canvasStart(htmlID string) bool {
 		return true
 	}
 
-	ErrorLog("No connection")
+	session.errorLog("No connection")
 	return false
 }
 
@@ -668,7 +868,7 @@ func (session *sessionData) canvasTextMetrics(htmlID, font, text string) TextMet
 		return session.bridge.canvasTextMetrics(htmlID, font, text)
 	}
 
-	ErrorLog("No connection")
+	session.errorLog("No connection")
 	return TextMetrics{Width: 0}
 }
 
@@ -677,7 +877,7 @@ func (session *sessionData) htmlPropertyValue(htmlID, name string) string {
 		return session.bridge.htmlPropertyValue(htmlID, name)
 	}
 
-	ErrorLog("No connection")
+	session.errorLog("No connection")
 	return ""
 }
 
@@ -706,7 +906,7 @@ func (session *sessionData) handleAnswer(command string, data DataObject) bool {
 	if session.bridge != nil {
 		session.bridge.sendResponse()
 	} else {
-		ErrorLog("No connection")
+		session.errorLog("No connection")
 	}
 	return true
 }
@@ -718,9 +918,9 @@ func (session *sessionData) handleRootSize(data DataObject) {
 			if err == nil {
 				return int(float)
 			}
-			ErrorLog(`Resize event error: ` + err.Error())
+			session.errorLog(`Resize event error: `+err.Error(), "command", "root-size")
 		} else {
-			ErrorLogF(`Resize event error: the property "%s" not found`, tag)
+			session.errorLog(`Resize event error: the property "`+tag+`" not found`, "command", "root-size")
 		}
 		return 0
 	}
@@ -744,9 +944,9 @@ func (session *sessionData) handleResize(data DataObject) {
 						if err == nil {
 							return f
 						}
-						ErrorLog(`Resize event error: ` + err.Error())
+						session.errorLog(`Resize event error: `+err.Error(), "command", "resize")
 					} else {
-						ErrorLogF(`Resize event error: the property "%s" not found`, tag)
+						session.errorLog(`Resize event error: the property "`+tag+`" not found`, "command", "resize")
 					}
 					return 0
 				}
@@ -755,23 +955,23 @@ func (session *sessionData) handleResize(data DataObject) {
 						if view := session.viewByHTMLID(viewID[:n]); view != nil {
 							view.onItemResize(view, viewID[n+1:], getFloat("x"), getFloat("y"), getFloat("width"), getFloat("height"))
 						} else {
-							DebugLogF(`View with id == %s not found`, viewID[:n])
+							session.Logger().Debug("View not found", "command", "resize", "view", viewID[:n])
 						}
 					} else if view := session.viewByHTMLID(viewID); view != nil {
 						view.onResize(view, getFloat("x"), getFloat("y"), getFloat("width"), getFloat("height"))
 						view.setScroll(getFloat("scroll-x"), getFloat("scroll-y"), getFloat("scroll-width"), getFloat("scroll-height"))
 					} else {
-						DebugLogF(`View with id == %s not found`, viewID)
+						session.Logger().Debug("View not found", "command", "resize", "view", viewID)
 					}
 				} else {
-					ErrorLog(`"id" property not found`)
+					session.errorLog(`"id" property not found`, "command", "resize")
 				}
 			} else {
-				ErrorLog(`Resize event error: views element is not object`)
+				session.errorLog(`Resize event error: views element is not object`, "command", "resize")
 			}
 		}
 	} else {
-		ErrorLog(`Resize event error: invalid "views" property`)
+		session.errorLog(`Resize event error: invalid "views" property`, "command", "resize")
 	}
 }
 
@@ -816,9 +1016,22 @@ func (session *sessionData) handleSessionInfo(params DataObject) {
 }
 
 func (session *sessionData) handleEvent(command string, data DataObject) {
+	session.runQueuedCalls()
+
 	switch command {
+	case "session-call":
+		// the queued functions are called above. The event is not a request of the client, so the output
+		// is not sent as a response: the HTTP client does not wait for it and receives it with the next response
+		session.bridge.finishBatch()
+		return
+
 	case "start-session":
-		if session.setContent(session.App().getCreateContentFunc()(session)) {
+		if session.rootView != nil {
+			// the root view was prerendered into the start page (see AppParams.Prerender)
+			session.handleSessionInfo(data)
+			session.reload()
+			session.onStart()
+		} else if session.setContent(session.App().getCreateContentFunc()(session)) {
 			session.writeInitScript()
 			session.onStart()
 		}
@@ -836,13 +1049,13 @@ func (session *sessionData) handleEvent(command string, data DataObject) {
 				if fn, ok := session.timers[timerID]; ok {
 					fn(session)
 				} else {
-					ErrorLog(`Timer (id = ` + text + `) not exists`)
+					session.errorLog(`Timer (id = `+text+`) not exists`, "command", command)
 				}
 			} else {
-				ErrorLog(err.Error())
+				session.errorLog(err.Error(), "command", command)
 			}
 		} else {
-			ErrorLog(`"timerID" property not found`)
+			session.errorLog(`"timerID" property not found`, "command", command)
 		}
 
 	case "root-size":
@@ -854,11 +1067,19 @@ func (session *sessionData) handleEvent(command string, data DataObject) {
 	case "sessionInfo":
 		session.handleSessionInfo(data)
 
+	case "inspector-pick":
+		session.handleInspectorPick(data)
+
+	case "server-shutdown":
+		session.onShutdown()
+
 	default:
 		if viewID, ok := data.PropertyValue("id"); ok {
 			if viewID != "body" {
 				if view := session.viewByHTMLID(viewID); view != nil {
 					view.handleCommand(view, PropertyName(command), data)
+				} else {
+					session.Logger().Debug("View not found", "command", command, "view", viewID)
 				}
 			}
 			if command == string(KeyDownEvent) {
@@ -867,7 +1088,7 @@ func (session *sessionData) handleEvent(command string, data DataObject) {
 				session.hotKey(event)
 			}
 		} else {
-			ErrorLog(`"id" property not found. Event: ` + command)
+			session.errorLog(`"id" property not found`, "command", command)
 		}
 	}
 
@@ -934,6 +1155,20 @@ func (session *sessionData) SetHotKey(keyCode KeyCode, controlKeys ControlKeyMas
 	}
 }
 
+func (session *sessionData) FocusView(view View) {
+	if view != nil {
+		session.callFunc("focus", view.htmlID())
+	}
+}
+
+func (session *sessionData) FocusNext() {
+	session.callFunc("focusNext")
+}
+
+func (session *sessionData) FocusPrevious() {
+	session.callFunc("focusPrevious")
+}
+
 func (session *sessionData) SetTitle(title string) {
 	title, _ = session.GetString(title)
 	session.callFunc("setTitle", title)
@@ -947,6 +1182,14 @@ func (session *sessionData) RemoteAddr() string {
 	return session.bridge.remoteAddr()
 }
 
+func (session *sessionData) ClientID() string {
+	return session.clientID
+}
+
+func (session *sessionData) setClientID(clientID string) {
+	session.clientID = clientID
+}
+
 func (session *sessionData) OpenURL(urlStr string) {
 	if _, err := url.ParseRequestURI(urlStr); err != nil {
 		ErrorLog(err.Error())
@@ -955,10 +1198,68 @@ func (session *sessionData) OpenURL(urlStr string) {
 	session.callFunc("openURL", urlStr)
 }
 
-func (session *sessionData) addToEventsQueue(data DataObject) {
+func (session *sessionData) RunScript(script string) {
+	if session.app == nil || !session.app.Params().AllowScripts {
+		session.errorLog("Scripts are disabled (see AppParams.AllowScripts)")
+		return
+	}
+	session.writeScript(script)
+}
+
+// queueCall adds the function to the queue of the session. The functions of the queue are called
+// in the goroutine of the session before the handling of the next event
+func (session *sessionData) queueCall(fn func(Session)) {
+	session.callsMutex.Lock()
+	session.calls = append(session.calls, fn)
+	session.callsMutex.Unlock()
+
+	// the event wakes up the goroutine of the session. If the queue of events is full or the session
+	// is disconnected then the functions are called with the next event
+	session.eventsMutex.RLock()
 	if session.events != nil {
-		session.events <- data
+		select {
+		case session.events <- NewDataObject("session-call"):
+		default:
+		}
 	}
+	session.eventsMutex.RUnlock()
+}
+
+func (session *sessionData) runQueuedCalls() {
+	session.callsMutex.Lock()
+	calls := session.calls
+	session.calls = nil
+	session.callsMutex.Unlock()
+
+	for _, fn := range calls {
+		fn(session)
+	}
+}
+
+func (session *sessionData) BroadcastToClient(fn func(Session)) {
+	if session.clientID == "" || session.app == nil {
+		fn(session)
+		return
+	}
+
+	for _, other := range session.app.clientSessions(session.clientID) {
+		if other == Session(session) {
+			fn(session)
+		} else {
+			other.queueCall(fn)
+		}
+	}
+}
+
+func (session *sessionData) Logout() {
+	session.BroadcastToClient(func(session Session) {
+		session.setUser(nil)
+		session.callFunc("reloadPage")
+	})
+}
+
+func (session *sessionData) addToEventsQueue(data DataObject) {
+	session.postEvent(context.Background(), data)
 }
 
 func (session *sessionData) StartTimer(ms int, timerFunc func(Session)) int {
@@ -43,6 +43,50 @@ type SessionReconnectListener interface {
 	OnReconnect(session Session)
 }
 
+// SessionShutdownListener is the listener interface of a server shutdown event
+type SessionShutdownListener interface {
+	// OnShutdown is a function that is called by the library when the server is going to shut down.
+	// The client is still connected, OnFinish is called after it
+	OnShutdown(session Session)
+}
+
+// SessionConnectionListener is the listener interface of a change of the connection state of a session
+type SessionConnectionListener interface {
+	// OnConnectionStateChanged is a function that is called by the library when the connection state of the session
+	// is changed. The state is SessionConnected, SessionDisconnected, or SessionClosed (see Session.ConnectionState)
+	OnConnectionStateChanged(session Session, state int)
+}
+
+// Constants of the connection state of a session (see Session.ConnectionState)
+const (
+	// SessionConnecting - the session is created, but the client is not connected yet (see AppParams.Prerender)
+	SessionConnecting = 0
+
+	// SessionConnected - the client is connected to the session
+	SessionConnected = 1
+
+	// SessionDisconnected - the connection with the client is lost. The client tries to restore it
+	// (see AppParams.ReconnectDelay), the events of the client are sent after the reconnection
+	SessionDisconnected = 2
+
+	// SessionClosed - the session is finished
+	SessionClosed = 3
+)
+
+// ClientSessionStartListener is the listener interface of a start of another session of the same client (see Session.ClientID)
+type ClientSessionStartListener interface {
+	// OnClientSessionStart is a function that is called by the library when the client of the session
+	// opens the application in another browser tab/window. The argument is the id of the new session
+	OnClientSessionStart(session Session, otherSessionID int)
+}
+
+// ClientSessionFinishListener is the listener interface of a finish of another session of the same client (see Session.ClientID)
+type ClientSessionFinishListener interface {
+	// OnClientSessionFinish is a function that is called by the library when another session of the client
+	// of the session is finished (e.g. the browser tab is closed). The argument is the id of the finished session
+	OnClientSessionFinish(session Session, otherSessionID int)
+}
+
 func (session *sessionData) onStart() {
 	if session.content != nil {
 		if listener, ok := session.content.(SessionStartListener); ok {
@@ -59,6 +103,23 @@ func (session *sessionData) onFinish() {
 			listener.OnFinish(session)
 		}
 	}
+	session.setConnectionState(SessionClosed)
+}
+
+func (session *sessionData) onShutdown() {
+	if session.content != nil {
+		if listener, ok := session.content.(SessionShutdownListener); ok {
+			listener.OnShutdown(session)
+		}
+	}
+
+	if session.bridge != nil {
+		message, _ := session.GetString(session.app.Params().ShutdownMessage)
+		if message == "" {
+			message, _ = session.GetString(DefaultShutdownMessage)
+		}
+		session.bridge.callFunc("showShutdownBanner", message)
+	}
 }
 
 func (session *sessionData) onPause() {
@@ -95,6 +156,7 @@ func (session *sessionData) onDisconnect() {
 			listener.OnDisconnect(session)
 		}
 	}
+	session.setConnectionState(SessionDisconnected)
 }
 
 func (session *sessionData) onReconnect() {
@@ -103,4 +165,33 @@ func (session *sessionData) onReconnect() {
 			listener.OnReconnect(session)
 		}
 	}
+	session.setConnectionState(SessionConnected)
+}
+
+func (session *sessionData) ConnectionState() int {
+	return int(session.connectionState.Load())
+}
+
+func (session *sessionData) setConnectionState(state int) {
+	if int(session.connectionState.Swap(int32(state))) != state && session.content != nil {
+		if listener, ok := session.content.(SessionConnectionListener); ok {
+			listener.OnConnectionStateChanged(session, state)
+		}
+	}
+}
+
+func (session *sessionData) onClientSessionStart(otherSessionID int) {
+	if session.content != nil {
+		if listener, ok := session.content.(ClientSessionStartListener); ok {
+			listener.OnClientSessionStart(session, otherSessionID)
+		}
+	}
+}
+
+func (session *sessionData) onClientSessionFinish(otherSessionID int) {
+	if session.content != nil {
+		if listener, ok := session.content.(ClientSessionFinishListener); ok {
+			listener.OnClientSessionFinish(session, otherSessionID)
+		}
+	}
 }
@@ -0,0 +1,64 @@
+package rui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnectionState(t *testing.T) {
+	app := createTestApp(t, AppParams{ReconnectDelay: 2 * time.Second})
+
+	page := app.getStartPage(httptest.NewRequest(http.MethodGet, "/", nil), "", nil, "")
+	if !strings.Contains(page, "const reconnectPolicy = { delay: 2000, maxDelay: 30000 };") {
+		t.Error("the reconnect policy is not found in the start page")
+	}
+
+	for _, test := range []struct {
+		delay, maxDelay, expectedDelay, expectedMax time.Duration
+	}{
+		{0, 0, DefaultReconnectDelay, DefaultMaxReconnectDelay},
+		{time.Minute, 0, time.Minute, time.Minute},
+		{0, 5 * time.Second, DefaultReconnectDelay, 5 * time.Second},
+	} {
+		params := AppParams{ReconnectDelay: test.delay, MaxReconnectDelay: test.maxDelay}
+		if delay, maxDelay := params.reconnectDelays(); delay != test.expectedDelay || maxDelay != test.expectedMax {
+			t.Errorf("reconnectDelays(%v, %v) = %v, %v", test.delay, test.maxDelay, delay, maxDelay)
+		}
+	}
+
+	params, _ := ParseDataText("start-session{session=5}")
+	session := app.createSession(params, nil, nil, nil, nil, "")
+	if session == nil {
+		t.Fatal("the session is not created")
+	}
+	if state := session.ConnectionState(); state != SessionConnected {
+		t.Errorf("the state of the new session is %d", state)
+	}
+
+	states := []int{}
+	session.(*sessionData).content = connectionTestContent{states: &states}
+	session.onDisconnect()
+	session.onReconnect()
+	session.onReconnect()
+	session.onFinish()
+
+	if expected := []int{SessionDisconnected, SessionConnected, SessionClosed}; !slices.Equal(states, expected) {
+		t.Errorf("states: %v, expected: %v", states, expected)
+	}
+	if state := session.ConnectionState(); state != SessionClosed {
+		t.Errorf("the state of the finished session is %d", state)
+	}
+}
+
+type connectionTestContent struct {
+	testContent
+	states *[]int
+}
+
+func (content connectionTestContent) OnConnectionStateChanged(session Session, state int) {
+	*content.states = append(*content.states, state)
+}
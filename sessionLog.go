@@ -0,0 +1,34 @@
+package rui
+
+import "log/slog"
+
+// logAttrs returns the slog arguments which identify the session
+func (session *sessionData) logAttrs() []any {
+	if session.bridge != nil {
+		return []any{slog.Int("session", session.sessionID), slog.String("remote", session.bridge.remoteAddr())}
+	}
+	return []any{slog.Int("session", session.sessionID)}
+}
+
+func (session *sessionData) Logger() *slog.Logger {
+	return Logger().With(session.logAttrs()...)
+}
+
+func (session *sessionData) LastError() string {
+	session.lastErrorMutex.Lock()
+	defer session.lastErrorMutex.Unlock()
+	return session.lastError
+}
+
+func (session *sessionData) setLastError(text string) {
+	session.lastErrorMutex.Lock()
+	session.lastError = text
+	session.lastErrorMutex.Unlock()
+}
+
+// errorLog writes the error with the attributes of the session and the slog arguments to the error log
+func (session *sessionData) errorLog(text string, args ...any) {
+	session.setLastError(text)
+	setLastError(text)
+	logError(text, append(session.logAttrs(), args...)...)
+}
@@ -0,0 +1,53 @@
+package rui
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	buffer := new(strings.Builder)
+	SetLogger(slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: LevelProtocol})))
+	defer SetLogger(nil)
+
+	session1 := newSession(nil, 1, "", nil).(*sessionData)
+	session2 := newSession(nil, 2, "", nil).(*sessionData)
+	session1.setBridge(nil, createHttpBridge(httptest.NewRequest(http.MethodPost, "/", nil)))
+	session1.handleEvent("timer", NewDataObject("timer"))
+	if session1.LastError() != `"timerID" property not found` || session2.LastError() != "" {
+		t.Errorf(`LastError = "%s", "%s"`, session1.LastError(), session2.LastError())
+	}
+
+	text := buffer.String()
+	for _, attr := range []string{"level=ERROR", "session=1", "remote=192.0.2.1:1234", "command=timer", "stack="} {
+		if !strings.Contains(text, attr) {
+			t.Errorf("the log does not contain %s: %s", attr, text)
+		}
+	}
+
+	if !protocolLogEnabled() {
+		t.Error("the protocol is not logged with LevelProtocol")
+	}
+
+	buffer.Reset()
+	view := NewTextView(session2, nil)
+	view.Set(Visibility, "unknown")
+	if session2.LastError() != `Invalid value "unknown" of "visibility" property` || session1.LastError() != `"timerID" property not found` {
+		t.Errorf(`LastError = "%s", "%s"`, session1.LastError(), session2.LastError())
+	}
+
+	SetLogger(nil)
+	lines := []string{}
+	SetErrorLog(func(text string) {
+		lines = append(lines, text)
+	})
+	defer SetErrorLog(errorLog)
+
+	session1.Logger().Error("error", "view", "id000001")
+	if len(lines) != 1 || lines[0] != "error session=1 remote=192.0.2.1:1234 view=id000001" {
+		t.Errorf("callback log = %q", lines)
+	}
+}
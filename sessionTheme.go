@@ -36,6 +36,11 @@ func (session *sessionData) constant(tag string, prevTags []string) (string, boo
 			return result, true
 		}
 
+		if isColorFunction(result) {
+			color, ok := session.colorFunction(result, session.darkTheme, []string{})
+			return color.String(), ok
+		}
+
 		if strings.ContainsAny(result, ", :;|/") {
 			return session.resolveConstantsNext(result, tags)
 		}
@@ -116,12 +121,15 @@ func (session *sessionData) getCurrentTheme() Theme {
 
 	if session.customTheme != nil {
 		session.currentTheme = NewTheme("")
-		session.currentTheme.Append(defaultTheme)
+		session.currentTheme.Append(DefaultTheme())
+		for _, parent := range themeAncestors(session.customTheme) {
+			session.currentTheme.Append(parent)
+		}
 		session.currentTheme.Append(session.customTheme)
 		return session.currentTheme
 	}
 
-	return defaultTheme
+	return DefaultTheme()
 }
 
 func (session *sessionData) lightDarkSupport() bool {
@@ -129,7 +137,16 @@ func (session *sessionData) lightDarkSupport() bool {
 }
 
 func (session *sessionData) getColor(tag string, darkMode bool) (Color, bool) {
-	tags := []string{tag}
+	return session.getColorNext(tag, darkMode, []string{})
+}
+
+func (session *sessionData) getColorNext(tag string, darkMode bool, prevTags []string) (Color, bool) {
+	if slices.Contains(prevTags, tag) {
+		ErrorLogF(`"%v" color is cyclic`, tag)
+		return 0, false
+	}
+
+	tags := append(slices.Clone(prevTags), tag)
 	theme := session.getCurrentTheme()
 	for {
 		result := theme.color(tag, darkMode)
@@ -138,6 +155,10 @@ func (session *sessionData) getColor(tag string, darkMode bool) (Color, bool) {
 			return 0, false
 		}
 
+		if isColorFunction(result) {
+			return session.colorFunction(result, darkMode, tags)
+		}
+
 		if len(result) == 0 || result[0] != '@' {
 			color, err := stringToColor(result)
 			if err != nil {
@@ -188,18 +209,40 @@ func (session *sessionData) ImageConstant(tag string) (string, bool) {
 
 func (session *sessionData) SetCustomTheme(name string) bool {
 	if name == "" {
-		if session.customTheme == nil {
-			return true
+		if session.customTheme != nil {
+			session.setCustomTheme(nil)
 		}
-	} else if theme, ok := resources.themes[name]; ok {
-		session.customTheme = theme
-		session.currentTheme = nil
-	} else {
-		return false
+		return true
 	}
 
-	session.reload()
-	return true
+	theme, ok := resources.theme(name)
+	if ok {
+		session.setCustomTheme(theme)
+	}
+	return ok
+}
+
+func (session *sessionData) getCustomTheme() Theme {
+	return session.customTheme
+}
+
+// setCustomTheme replaces the custom theme with the theme object (nil - the default theme) and updates the page
+func (session *sessionData) setCustomTheme(theme Theme) {
+	session.customTheme = theme
+	session.currentTheme = nil
+	if session.bridge != nil {
+		session.reload()
+	}
+}
+
+// updateCustomTheme changes the custom theme like setCustomTheme, but only the styles are updated on the client,
+// the views are not rebuilt. It is used when the changed values of the theme are not used by the views directly
+func (session *sessionData) updateCustomTheme(theme Theme) {
+	session.customTheme = theme
+	session.currentTheme = nil
+	if session.bridge != nil {
+		session.updateStyles()
+	}
 }
 
 const checkImage = `<svg width="16" height="16" version="1.1" viewBox="0 0 16 16" xmlns="http://www.w3.org/2000/svg"><path d="m4 8 3 4 5-8" fill="none" stroke="#fff" stroke-linecap="round" stroke-linejoin="round" stroke-width="2.5"/></svg>`
@@ -1,7 +1,9 @@
 package rui
 
 import (
+	"context"
 	"testing"
+	"time"
 )
 
 // var stopTestLogFlag = false
@@ -20,6 +22,27 @@ func createTestLog(t *testing.T, ignore bool) {
 	SetDebugLog(func(text string) {
 		t.Log(text)
 	})
+	t.Cleanup(func() {
+		SetErrorLog(nil)
+		SetDebugLog(nil)
+	})
+}
+
+// testContent is the session content of the test applications
+type testContent struct{}
+
+func (content testContent) CreateRootView(session Session) View {
+	return NewTextView(session, Params{Text: "Landing page"})
+}
+
+// createTestApp creates the application with testContent sessions.
+// The application is removed from the list of applications at the end of the test
+func createTestApp(t *testing.T, params AppParams) *application {
+	app := newApplication(func(Session) SessionContent {
+		return testContent{}
+	}, params)
+	t.Cleanup(app.removeFromApps)
+	return app
 }
 
 /*
@@ -120,3 +143,40 @@ func TestSessionConstants(t *testing.T) {
 	}
 }
 */
+
+func TestPostEvent(t *testing.T) {
+	session := newSession(nil, 1, "", nil).(*sessionData)
+	if session.postEvent(context.Background(), NewDataObject("nop")) {
+		t.Error("the event is sent to the disconnected session")
+	}
+
+	events := make(chan DataObject, 1)
+	session.setBridge(events, nil)
+	if !session.postEvent(context.Background(), NewDataObject("nop")) {
+		t.Fatal("the event is not sent")
+	}
+
+	// the queue is full, the waiting sender must not block the replacement of the queue
+	result := make(chan bool)
+	go func() {
+		result <- session.postEvent(context.Background(), NewDataObject("nop"))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	session.setBridge(nil, nil)
+
+	select {
+	case ok := <-result:
+		if ok {
+			t.Error("the event is sent to the replaced queue")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("postEvent is not finished after setBridge")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	session.setBridge(make(chan DataObject), nil)
+	if session.postEvent(ctx, NewDataObject("nop")) {
+		t.Error("the event is sent after the context is done")
+	}
+}
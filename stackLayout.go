@@ -358,8 +358,8 @@ func (layout *stackLayoutData) transitionFinished(view View, tag PropertyName) {
 					session.updateCSSProperty(pageID, "visibility", "hidden")
 					session.updateCSSProperty(pageID, "transition", "")
 					session.updateCSSProperty(pageID, "transform", "")
-					session.removeProperty(pageID, "ontransitionend")
-					session.removeProperty(pageID, "ontransitioncancel")
+					session.removeProperty(pageID, "data-ontransitionend")
+					session.removeProperty(pageID, "data-ontransitioncancel")
 					session.updateCSSProperty(pageID, "z-index", "auto")
 					session.finishUpdateScript(pageID)
 				}
@@ -368,8 +368,8 @@ func (layout *stackLayoutData) transitionFinished(view View, tag PropertyName) {
 				session.startUpdateScript(pageID)
 				session.updateCSSProperty(pageID, "z-index", "auto")
 				session.updateCSSProperty(pageID, "transition", "")
-				session.removeProperty(pageID, "ontransitionend")
-				session.removeProperty(pageID, "ontransitioncancel")
+				session.removeProperty(pageID, "data-ontransitionend")
+				session.removeProperty(pageID, "data-ontransitioncancel")
 				session.finishUpdateScript(pageID)
 
 				for _, listener := range finished.listener {
@@ -394,8 +394,8 @@ func (layout *stackLayoutData) transitionFinished(view View, tag PropertyName) {
 				if count := len(layout.views); count > 0 {
 					peekID := layout.views[count-1].htmlID() + "page"
 					session.startUpdateScript(peekID)
-					session.removeProperty(peekID, "ontransitionend")
-					session.removeProperty(peekID, "ontransitioncancel")
+					session.removeProperty(peekID, "data-ontransitionend")
+					session.removeProperty(peekID, "data-ontransitioncancel")
 					session.finishUpdateScript(peekID)
 				}
 			}
@@ -415,8 +415,8 @@ func (layout *stackLayoutData) transitionFinished(view View, tag PropertyName) {
 			session.startUpdateScript(pageID)
 			session.updateCSSProperty(pageID, "z-index", "auto")
 			session.updateCSSProperty(pageID, "transition", "")
-			session.removeProperty(pageID, "ontransitionend")
-			session.removeProperty(pageID, "ontransitioncancel")
+			session.removeProperty(pageID, "data-ontransitionend")
+			session.removeProperty(pageID, "data-ontransitioncancel")
 			session.finishUpdateScript(pageID)
 			layout.runChangeListener(Content)
 
@@ -599,8 +599,8 @@ func (layout *stackLayoutData) moveToFrontByIndex(index int, onShow []func(View)
 	session.updateCSSProperty(peekPageID, "transition", transitionCSS)
 
 	session.startUpdateScript(pageID)
-	session.updateProperty(pageID, "ontransitionend", listener)
-	session.updateProperty(pageID, "ontransitioncancel", listener)
+	session.updateProperty(pageID, "data-ontransitionend", listener)
+	session.updateProperty(pageID, "data-ontransitioncancel", listener)
 	session.updateCSSProperty(pageID, "transform", transformCSS)
 	session.updateCSSProperty(pageID, "z-index", "100")
 	session.updateCSSProperty(pageID, "visibility", "visible")
@@ -789,7 +789,7 @@ func (layout *stackLayoutData) Push(view View, onPushFinished ...func()) {
 
 	buffer.WriteString(`<div id="`)
 	buffer.WriteString(htmlID)
-	buffer.WriteString(`page" class="ruiStackPageLayout" ontransitionend="stackTransitionEndEvent('`)
+	buffer.WriteString(`page" class="ruiStackPageLayout" data-ontransitionend="stackTransitionEndEvent('`)
 	buffer.WriteString(layout.htmlID())
 	buffer.WriteString(`', 'push-`)
 	buffer.WriteString(htmlID)
@@ -866,8 +866,8 @@ func (layout *stackLayoutData) Pop(onPopFinished ...func(View)) bool {
 	transitionCSS := layout.pushTransitionCSS()
 
 	session.startUpdateScript(pageID)
-	session.updateProperty(pageID, "ontransitionend", listener)
-	session.updateProperty(pageID, "ontransitioncancel", listener)
+	session.updateProperty(pageID, "data-ontransitionend", listener)
+	session.updateProperty(pageID, "data-ontransitioncancel", listener)
 	session.updateCSSProperty(pageID, "z-index", "100")
 	session.updateCSSProperty(pageID, "transition", transitionCSS)
 	session.finishUpdateScript(pageID)
@@ -0,0 +1,437 @@
+package rui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Plural categories returned by a plural rule (see SetPluralRule)
+const (
+	// PluralZero is the plural category for zero quantities (e.g. Arabic)
+	PluralZero = "zero"
+
+	// PluralOne is the plural category for singular quantities
+	PluralOne = "one"
+
+	// PluralTwo is the plural category for dual quantities (e.g. Arabic, Hebrew)
+	PluralTwo = "two"
+
+	// PluralFew is the plural category for "paucal" quantities (e.g. 2-4 in Russian or Polish)
+	PluralFew = "few"
+
+	// PluralMany is the plural category for "many" quantities (e.g. 5-20 in Russian or Polish)
+	PluralMany = "many"
+
+	// PluralOther is the general plural category, it is used if the other categories do not match
+	PluralOther = "other"
+)
+
+var pluralRules = struct {
+	sync.RWMutex
+	rules map[string]func(n float64) string
+}{rules: map[string]func(n float64) string{}}
+
+// SetPluralRule sets the function which returns the plural category (PluralZero, PluralOne, PluralTwo,
+// PluralFew, PluralMany or PluralOther) of the number for the language.
+// The built-in rules cover the most common languages, use this function for other languages or to replace them.
+func SetPluralRule(lang string, rule func(n float64) string) {
+	pluralRules.Lock()
+	defer pluralRules.Unlock()
+
+	if rule == nil {
+		delete(pluralRules.rules, lang)
+	} else {
+		pluralRules.rules[lang] = rule
+	}
+}
+
+func pluralCategory(lang string, n float64) string {
+	for _, lang := range languageChain(lang) {
+		pluralRules.RLock()
+		rule, ok := pluralRules.rules[lang]
+		pluralRules.RUnlock()
+		if ok {
+			return rule(n)
+		}
+	}
+
+	n = math.Abs(n)
+	integer := n == math.Trunc(n)
+	i := int64(n)
+	mod10 := i % 10
+	mod100 := i % 100
+
+	lang, _, _ = strings.Cut(strings.ToLower(lang), "-")
+	lang, _, _ = strings.Cut(lang, "_")
+
+	switch lang {
+	case "ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my", "km":
+		return PluralOther
+
+	case "fr", "pt", "hy", "kab":
+		if i == 0 || i == 1 {
+			return PluralOne
+		}
+
+	case "ru", "uk", "be":
+		switch {
+		case !integer:
+			return PluralOther
+
+		case mod10 == 1 && mod100 != 11:
+			return PluralOne
+
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		}
+		return PluralMany
+
+	case "pl":
+		switch {
+		case !integer:
+			return PluralOther
+
+		case i == 1:
+			return PluralOne
+
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		}
+		return PluralMany
+
+	case "cs", "sk":
+		switch {
+		case !integer:
+			return PluralMany
+
+		case i == 1:
+			return PluralOne
+
+		case i >= 2 && i <= 4:
+			return PluralFew
+		}
+
+	case "ar":
+		switch {
+		case !integer:
+			return PluralOther
+
+		case i == 0:
+			return PluralZero
+
+		case i == 1:
+			return PluralOne
+
+		case i == 2:
+			return PluralTwo
+
+		case mod100 >= 3 && mod100 <= 10:
+			return PluralFew
+
+		case mod100 >= 11:
+			return PluralMany
+		}
+
+	case "he":
+		switch {
+		case integer && i == 1:
+			return PluralOne
+
+		case integer && i == 2:
+			return PluralTwo
+		}
+
+	default:
+		if integer && i == 1 {
+			return PluralOne
+		}
+	}
+
+	return PluralOther
+}
+
+// FormatMessage formats the message which uses a subset of the ICU MessageFormat syntax:
+//   - {name} - the value of the "name" parameter;
+//   - {name, number}, {name, number, integer}, {name, number, percent} - the number;
+//   - {name, plural, =0 {no items} one {# item} other {# items}} - the plural form of the number.
+//     The plural category (zero, one, two, few, many, other) is selected by the language rules (see SetPluralRule),
+//     "#" is replaced by the number, the optional "offset:n" is subtracted from the number;
+//   - {name, select, male {He} female {She} other {They}} - the selection of the text by the value (e.g. a gender);
+//   - the apostrophe quotes the special characters: '{' and '}' are written as {}, two apostrophes are written as one.
+//
+// A parameter which is missing in "params" is written as is: {name}.
+func FormatMessage(lang, message string, params map[string]any) string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	formatter := messageFormatter{lang: lang, params: params, buffer: buffer}
+	formatter.format([]rune(message), "")
+	return buffer.String()
+}
+
+func (session *sessionData) FormatString(tag string, params map[string]any) string {
+	text, lang, _ := findString(tag, session.stringLanguages())
+	if lang == "" {
+		lang = session.language
+	}
+	return FormatMessage(lang, text, params)
+}
+
+type messageFormatter struct {
+	lang   string
+	params map[string]any
+	buffer *strings.Builder
+}
+
+func (formatter *messageFormatter) format(text []rune, number string) {
+	buffer := formatter.buffer
+	count := len(text)
+
+	for i := 0; i < count; i++ {
+		switch ch := text[i]; ch {
+		case '\'':
+			switch {
+			case i+1 < count && text[i+1] == '\'':
+				buffer.WriteRune('\'')
+				i++
+
+			case i+1 < count && strings.ContainsRune("{}#", text[i+1]):
+				i++
+				for ; i < count; i++ {
+					if text[i] == '\'' {
+						if i+1 < count && text[i+1] == '\'' {
+							buffer.WriteRune('\'')
+							i++
+						} else {
+							break
+						}
+					} else {
+						buffer.WriteRune(text[i])
+					}
+				}
+
+			default:
+				buffer.WriteRune(ch)
+			}
+
+		case '#':
+			if number != "" {
+				buffer.WriteString(number)
+			} else {
+				buffer.WriteRune(ch)
+			}
+
+		case '{':
+			end := messageBlockEnd(text, i)
+			if end < 0 {
+				buffer.WriteString(string(text[i:]))
+				return
+			}
+			formatter.argument(text[i+1 : end])
+			i = end
+
+		default:
+			buffer.WriteRune(ch)
+		}
+	}
+}
+
+// messageBlockEnd returns the index of '}' closing the block started at "start" index or -1
+func messageBlockEnd(text []rune, start int) int {
+	depth := 0
+	quoted := false
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\'':
+			if i+1 < len(text) && (text[i+1] == '\'' || (!quoted && strings.ContainsRune("{}#", text[i+1]))) {
+				if text[i+1] == '\'' {
+					i++
+				} else {
+					quoted = true
+				}
+			} else if quoted {
+				quoted = false
+			}
+
+		case '{':
+			if !quoted {
+				depth++
+			}
+
+		case '}':
+			if !quoted {
+				depth--
+				if depth == 0 {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func (formatter *messageFormatter) argument(arg []rune) {
+	parts := make([]string, 0, 3)
+	rest := arg
+	for len(parts) < 2 {
+		index := -1
+		for i, ch := range rest {
+			if ch == ',' {
+				index = i
+				break
+			}
+			if ch == '{' {
+				break
+			}
+		}
+		if index < 0 {
+			break
+		}
+		parts = append(parts, strings.TrimSpace(string(rest[:index])))
+		rest = rest[index+1:]
+	}
+
+	if len(parts) == 0 {
+		parts = append(parts, strings.TrimSpace(string(rest)))
+		rest = nil
+	} else if len(parts) == 1 {
+		parts = append(parts, strings.TrimSpace(string(rest)))
+		rest = nil
+	}
+
+	name := parts[0]
+	value, ok := formatter.params[name]
+	if !ok {
+		formatter.buffer.WriteRune('{')
+		formatter.buffer.WriteString(string(arg))
+		formatter.buffer.WriteRune('}')
+		return
+	}
+
+	argType := ""
+	if len(parts) > 1 {
+		argType = parts[1]
+	}
+
+	switch argType {
+	case "":
+		formatter.buffer.WriteString(fmt.Sprint(value))
+
+	case "number":
+		formatter.buffer.WriteString(formatter.number(value, strings.TrimSpace(string(rest))))
+
+	case "plural":
+		n, ok := messageNumber(value)
+		if !ok {
+			formatter.buffer.WriteString(fmt.Sprint(value))
+			return
+		}
+
+		options, offset := messageOptions(rest)
+		message, ok := options["="+strconv.FormatFloat(n, 'f', -1, 64)]
+		if !ok {
+			if message, ok = options[pluralCategory(formatter.lang, n-offset)]; !ok {
+				message = options[PluralOther]
+			}
+		}
+		formatter.format(message, formatter.number(n-offset, ""))
+
+	case "select":
+		options, _ := messageOptions(rest)
+		message, ok := options[fmt.Sprint(value)]
+		if !ok {
+			message = options[PluralOther]
+		}
+		formatter.format(message, "")
+
+	default:
+		formatter.buffer.WriteString(fmt.Sprint(value))
+	}
+}
+
+func (formatter *messageFormatter) number(value any, style string) string {
+	n, ok := messageNumber(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+
+	switch style {
+	case "integer":
+		return strconv.FormatFloat(math.Round(n), 'f', 0, 64)
+
+	case "percent":
+		return strconv.FormatFloat(math.Round(n*100), 'f', 0, 64) + "%"
+	}
+
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func messageNumber(value any) (float64, bool) {
+	switch value := value.(type) {
+	case float32:
+		return float64(value), true
+
+	case float64:
+		return value, true
+
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return n, err == nil
+	}
+
+	if n, ok := isInt(value); ok {
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// messageOptions parses the list of "selector {message}" pairs and the optional "offset:n" prefix
+func messageOptions(text []rune) (map[string][]rune, float64) {
+	options := map[string][]rune{}
+	offset := 0.0
+	count := len(text)
+
+	i := 0
+	for i < count {
+		for i < count && unicode.IsSpace(text[i]) {
+			i++
+		}
+
+		start := i
+		for i < count && text[i] != '{' && !unicode.IsSpace(text[i]) {
+			i++
+		}
+		selector := string(text[start:i])
+
+		if strings.HasPrefix(selector, "offset:") {
+			if n, err := strconv.ParseFloat(selector[7:], 64); err == nil {
+				offset = n
+			}
+			continue
+		}
+
+		for i < count && unicode.IsSpace(text[i]) {
+			i++
+		}
+
+		if i >= count || text[i] != '{' {
+			break
+		}
+
+		end := messageBlockEnd(text, i)
+		if end < 0 {
+			break
+		}
+
+		if selector != "" {
+			options[selector] = text[i+1 : end]
+		}
+		i = end + 1
+	}
+
+	return options, offset
+}
@@ -0,0 +1,60 @@
+package rui
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestFormatMessage(t *testing.T) {
+	createTestLog(t, false)
+
+	files := "{count, plural, =0 {No files} one {# file} few {# файла} many {# файлов} other {# files}}"
+	tests := []struct {
+		lang, message string
+		params        map[string]any
+		result        string
+	}{
+		{"en", "Hello, {name}!", map[string]any{"name": "Ann"}, "Hello, Ann!"},
+		{"en", "Hello, {name}!", nil, "Hello, {name}!"},
+		{"en", files, map[string]any{"count": 0}, "No files"},
+		{"en", files, map[string]any{"count": 1}, "1 file"},
+		{"en", files, map[string]any{"count": 21}, "21 files"},
+		{"ru", files, map[string]any{"count": 21}, "21 file"},
+		{"ru", files, map[string]any{"count": 3}, "3 файла"},
+		{"ru", files, map[string]any{"count": 11}, "11 файлов"},
+		{"en", "{gender, select, female {She} male {He} other {They}} left", map[string]any{"gender": "female"}, "She left"},
+		{"en", "{gender, select, female {She} male {He} other {They}} left", map[string]any{"gender": "x"}, "They left"},
+		{"en", "{p, number, percent} done, '{'quoted'}', it''s #", map[string]any{"p": 0.25}, "25% done, {quoted}, it's #"},
+		{"en", "{n, plural, offset:1 =0 {nobody} =1 {{name}} other {{name} and # others}}", map[string]any{"n": 3, "name": "Bob"}, "Bob and 2 others"},
+	}
+
+	for _, test := range tests {
+		if result := FormatMessage(test.lang, test.message, test.params); result != test.result {
+			t.Errorf(`FormatMessage("%s", "%s") = "%s", expected "%s"`, test.lang, test.message, result, test.result)
+		}
+	}
+}
+
+func TestPluralRuleConcurrency(t *testing.T) {
+	defer SetPluralRule("xx", nil)
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for range 100 {
+			SetPluralRule("xx", func(n float64) string {
+				return PluralMany
+			})
+		}
+	}()
+
+	for range 100 {
+		pluralCategory("xx", 1)
+	}
+	wait.Wait()
+
+	if category := pluralCategory("xx", 1); category != PluralMany {
+		t.Errorf(`pluralCategory("xx", 1) = "%s"`, category)
+	}
+}
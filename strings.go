@@ -4,11 +4,11 @@ import (
 	"embed"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 )
 
-var stringResources = map[string]map[string]string{}
-
 func (resources *resourceManager) scanEmbedStringsDir(fs *embed.FS, dir string) {
 	if files, err := fs.ReadDir(dir); err == nil {
 		for _, file := range files {
@@ -18,7 +18,7 @@ func (resources *resourceManager) scanEmbedStringsDir(fs *embed.FS, dir string)
 				resources.scanEmbedStringsDir(fs, path)
 			} else if strings.ToLower(filepath.Ext(name)) == ".rui" {
 				if data, err := fs.ReadFile(path); err == nil {
-					loadStringResources(string(data))
+					resources.loadStrings(path, string(data))
 				} else {
 					ErrorLog(err.Error())
 				}
@@ -37,7 +37,7 @@ func (resources *resourceManager) scanStringsDir(path string) {
 					resources.scanStringsDir(newPath)
 				} else if strings.ToLower(filepath.Ext(newPath)) == ".rui" {
 					if data, err := os.ReadFile(newPath); err == nil {
-						loadStringResources(string(data))
+						resources.loadStrings(newPath, string(data))
 					} else {
 						ErrorLog(err.Error())
 					}
@@ -49,15 +49,15 @@ func (resources *resourceManager) scanStringsDir(path string) {
 	}
 }
 
-func loadStringResources(text string) {
-	data, err := ParseDataText(text)
+func (resources *resourceManager) loadStrings(filename, text string) {
+	data, err := ParseDataFile(filename, text)
 	if err != nil {
 		ErrorLog(err.Error())
 		return
 	}
 
 	parseStrings := func(obj DataObject, lang string) {
-		table, ok := stringResources[lang]
+		table, ok := resources.strings[lang]
 		if !ok {
 			table = map[string]string{}
 		}
@@ -68,7 +68,7 @@ func loadStringResources(text string) {
 			}
 		}
 
-		stringResources[lang] = table
+		resources.strings[lang] = table
 	}
 
 	tag := data.Tag()
@@ -86,44 +86,134 @@ func loadStringResources(text string) {
 	}
 }
 
-// GetString returns the text for the language which is defined by "lang" parameter
-func GetString(tag, lang string) (string, bool) {
-	if table, ok := stringResources[lang]; ok {
-		if text, ok := table[tag]; ok {
-			return text, true
-		}
-		DebugLogF(`There is no "%s" string resource`, tag)
+// SetLanguageFallback sets the list of languages which are used (in the specified order) if the string
+// resource is not found for the "lang" language. If "lang" is empty then the list is used for all languages
+// after their own fallbacks.
+//
+// Without the explicit setting, the parent languages are used: "pt-BR" → "pt".
+// For example, the chain "pt-BR" → "pt" → "en" is set by
+//
+//	rui.SetLanguageFallback("", "en")
+func SetLanguageFallback(lang string, fallbacks ...string) {
+	languageFallbacks.Lock()
+	defer languageFallbacks.Unlock()
+
+	if len(fallbacks) == 0 {
+		delete(languageFallbacks.langs, lang)
+	} else {
+		languageFallbacks.langs[lang] = slices.Clone(fallbacks)
 	}
-	DebugLogF(`There are no "%s" language resources`, lang)
-	return tag, false
 }
 
-func (session *sessionData) GetString(tag string) (string, bool) {
-	getString := func(tag, lang string) (string, bool) {
-		if table, ok := stringResources[lang]; ok {
-			if text, ok := table[tag]; ok {
-				return text, true
+var languageFallbacks = struct {
+	sync.RWMutex
+	langs map[string][]string
+}{langs: map[string][]string{}}
+
+// languageChain returns the list of languages for the search of a string resource
+func languageChain(languages ...string) []string {
+	languageFallbacks.RLock()
+	defer languageFallbacks.RUnlock()
+
+	chain := []string{}
+
+	var addLang func(lang string)
+	addLang = func(lang string) {
+		for lang != "" {
+			if !slices.Contains(chain, lang) {
+				chain = append(chain, lang)
+				for _, fallback := range languageFallbacks.langs[lang] {
+					addLang(fallback)
+				}
+			}
+
+			if index := strings.LastIndexAny(lang, "-_"); index > 0 {
+				lang = lang[:index]
+			} else {
+				lang = ""
 			}
-			DebugLogF(`There is no "%s" string in "%s" resources`, tag, lang)
 		}
-		return tag, false
 	}
 
-	if session.language != "" {
-		if text, ok := getString(tag, session.language); ok {
-			return text, true
+	for _, lang := range languages {
+		addLang(strings.TrimSpace(lang))
+	}
+
+	for _, lang := range languageFallbacks.langs[""] {
+		addLang(lang)
+	}
+
+	return chain
+}
+
+// maxMissingStrings limits the number of the missing string tags which are remembered by logMissingString,
+// the set is cleared when the limit is reached (the text of views which is not a string resource is also requested)
+const maxMissingStrings = 1024
+
+var missingStrings = struct {
+	sync.Mutex
+	tags map[string]bool
+}{tags: map[string]bool{}}
+
+// logMissingString writes to the debug log the message about the missing string only once
+// for each tag (with the languages of the first request). Nothing is remembered if the debug log is disabled
+func logMissingString(tag string, languages []string) {
+	if !debugLogEnabled() {
+		return
+	}
+
+	missingStrings.Lock()
+	logged := missingStrings.tags[tag]
+	if !logged {
+		if len(missingStrings.tags) >= maxMissingStrings {
+			clear(missingStrings.tags)
 		}
+		missingStrings.tags[tag] = true
 	}
+	missingStrings.Unlock()
 
-	if session.languages != nil {
-		for _, lang := range session.languages {
-			if lang != session.language {
-				if text, ok := getString(tag, lang); ok {
-					return text, true
-				}
+	if !logged {
+		DebugLogF(`There is no "%s" string resource for languages: %s`, tag, strings.Join(languages, ", "))
+	}
+}
+
+func (resources *resourceManager) lookupString(tag string, languages []string) (string, string, bool) {
+	resources.textMutex.RLock()
+	defer resources.textMutex.RUnlock()
+
+	for _, lang := range languages {
+		if table, ok := resources.strings[lang]; ok {
+			if text, ok := table[tag]; ok {
+				return text, lang, true
 			}
 		}
 	}
+	return tag, "", false
+}
 
-	return tag, false
+func findString(tag string, languages []string) (string, string, bool) {
+	text, lang, ok := resources.lookupString(tag, languages)
+	if !ok && len(languages) > 0 {
+		logMissingString(tag, languages)
+	}
+	return text, lang, ok
+}
+
+func getString(tag string, languages []string) (string, bool) {
+	text, _, ok := findString(tag, languages)
+	return text, ok
+}
+
+// GetString returns the text for the language which is defined by "lang" parameter.
+// If the text is not found for the language then its fallback languages are used (see SetLanguageFallback)
+func GetString(tag, lang string) (string, bool) {
+	return getString(tag, languageChain(lang))
+}
+
+func (session *sessionData) stringLanguages() []string {
+	return languageChain(append([]string{session.language}, session.languages...)...)
+}
+
+func (session *sessionData) GetString(tag string) (string, bool) {
+	return getString(tag, session.stringLanguages())
 }
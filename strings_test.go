@@ -0,0 +1,91 @@
+package rui
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestGetStringFallback(t *testing.T) {
+	createTestLog(t, false)
+
+	resources.loadStrings("strings.rui", `strings {
+		en = _{ hello = Hello, bye = Bye },
+		pt = _{ hello = Olá },
+	}`)
+	defer func() {
+		delete(resources.strings, "en")
+		delete(resources.strings, "pt")
+	}()
+
+	SetLanguageFallback("", "en")
+	defer SetLanguageFallback("")
+
+	for _, test := range []struct{ tag, lang, result string }{
+		{"hello", "pt-BR", "Olá"},
+		{"bye", "pt-BR", "Bye"},
+		{"hello", "de", "Hello"},
+	} {
+		if result, ok := GetString(test.tag, test.lang); !ok || result != test.result {
+			t.Errorf(`GetString("%s", "%s") = "%s", expected "%s"`, test.tag, test.lang, result, test.result)
+		}
+	}
+
+	if _, ok := GetString("unknown", "pt"); ok {
+		t.Error(`GetString("unknown", "pt") result is true`)
+	}
+}
+
+func TestLanguageFallbackConcurrency(t *testing.T) {
+	createTestLog(t, false)
+	defer SetLanguageFallback("pt")
+
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		for range 100 {
+			SetLanguageFallback("pt", "es", "en")
+		}
+	}()
+
+	for range 100 {
+		GetString("hello", "pt-BR")
+	}
+	wait.Wait()
+
+	if chain := languageChain("pt-BR"); !slices.Equal(chain, []string{"pt-BR", "pt", "es", "en"}) {
+		t.Errorf(`languageChain("pt-BR") = %v`, chain)
+	}
+
+	missingStrings.Lock()
+	_, logged := missingStrings.tags["hello"]
+	missingStrings.Unlock()
+	if !logged {
+		t.Error(`the missing "hello" string is not logged`)
+	}
+}
+
+func TestMissingStringsLimit(t *testing.T) {
+	SetDebugLog(nil)
+	GetString("notLoggedString", "en")
+	missingStrings.Lock()
+	_, logged := missingStrings.tags["notLoggedString"]
+	missingStrings.Unlock()
+	if logged {
+		t.Error("the missing string is remembered while the debug log is disabled")
+	}
+
+	SetDebugLog(func(string) {})
+	defer SetDebugLog(nil)
+	for i := range maxMissingStrings + 10 {
+		GetString(strconv.Itoa(i), "en")
+	}
+	missingStrings.Lock()
+	count := len(missingStrings.tags)
+	missingStrings.Unlock()
+	if count > maxMissingStrings {
+		t.Errorf("%d missing strings are remembered, the limit is %d", count, maxMissingStrings)
+	}
+}
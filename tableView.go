@@ -6,6 +6,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Constants for [TableView] specific properties and events
@@ -667,6 +668,26 @@ func (table *tableViewData) setFunc(tag PropertyName, value any) []PropertyName
 	}
 
 	switch tag {
+	case Format:
+		switch value := value.(type) {
+		case []string:
+			return setStringPropertyValue(table, Format, strings.Join(value, ";"))
+
+		case string:
+			return setStringPropertyValue(table, Format, value)
+
+		case []DataValue:
+			formats := make([]string, 0, len(value))
+			for _, element := range value {
+				if !element.IsObject() {
+					formats = append(formats, element.Value())
+				}
+			}
+			return setStringPropertyValue(table, Format, strings.Join(formats, ";"))
+		}
+		notCompatibleType(tag, value)
+		return nil
+
 	case Content:
 		switch val := value.(type) {
 		case TableAdapter:
@@ -864,7 +885,7 @@ func (table *tableViewData) propertyChanged(tag PropertyName) {
 		CellBorder, HeadHeight, HeadStyle, FootHeight, FootStyle,
 		CellPaddingTop, CellPaddingRight, CellPaddingBottom, CellPaddingLeft,
 		TableCellClickedEvent, TableCellSelectedEvent, TableRowClickedEvent,
-		TableRowSelectedEvent, AllowSelection, AccentColor:
+		TableRowSelectedEvent, AllowSelection, AccentColor, Format:
 		ReloadTableViewData(table)
 
 	case Current:
@@ -901,46 +922,53 @@ func (table *tableViewData) propertyChanged(tag PropertyName) {
 		case CellSelection:
 			tabIndex, _ := intProperty(table, TabIndex, session, 0)
 			session.updateProperty(htmlID, "tabindex", tabIndex)
-			session.updateProperty(htmlID, "onfocus", "tableViewFocusEvent(this, event)")
-			session.updateProperty(htmlID, "onblur", "tableViewBlurEvent(this, event)")
+			session.updateProperty(htmlID, "data-onfocus", "tableViewFocusEvent(this, event)")
+			session.updateProperty(htmlID, "data-onblur", "tableViewBlurEvent(this, event)")
 			session.updateProperty(htmlID, "data-selection", "cell")
 			session.updateProperty(htmlID, "data-focusitemstyle", tableViewCurrentStyle(table))
 			session.updateProperty(htmlID, "data-bluritemstyle", tableViewCurrentInactiveStyle(table))
 
 			current := tableViewCurrent(table)
 			if current.Row >= 0 && current.Column >= 0 {
-				session.updateProperty(htmlID, "data-current", tableViewCellID(table, current.Row, current.Column))
+				cellID := tableViewCellID(table, current.Row, current.Column)
+				session.updateProperty(htmlID, "data-current", cellID)
+				session.updateProperty(htmlID, "aria-activedescendant", cellID)
 			} else {
 				session.removeProperty(htmlID, "data-current")
+				session.removeProperty(htmlID, "aria-activedescendant")
 			}
-			session.updateProperty(htmlID, "onkeydown", "tableViewCellKeyDownEvent(this, event)")
+			session.updateProperty(htmlID, "data-onkeydown", "tableViewCellKeyDownEvent(this, event)")
 
 		case RowSelection:
 			tabIndex, _ := intProperty(table, TabIndex, session, 0)
 			session.updateProperty(htmlID, "tabindex", tabIndex)
-			session.updateProperty(htmlID, "onfocus", "tableViewFocusEvent(this, event)")
-			session.updateProperty(htmlID, "onblur", "tableViewBlurEvent(this, event)")
+			session.updateProperty(htmlID, "data-onfocus", "tableViewFocusEvent(this, event)")
+			session.updateProperty(htmlID, "data-onblur", "tableViewBlurEvent(this, event)")
 			session.updateProperty(htmlID, "data-selection", "row")
 			session.updateProperty(htmlID, "data-focusitemstyle", tableViewCurrentStyle(table))
 			session.updateProperty(htmlID, "data-bluritemstyle", tableViewCurrentInactiveStyle(table))
 
 			current := tableViewCurrent(table)
 			if current.Row >= 0 {
-				session.updateProperty(htmlID, "data-current", tableViewRowID(table, current.Row))
+				rowID := tableViewRowID(table, current.Row)
+				session.updateProperty(htmlID, "data-current", rowID)
+				session.updateProperty(htmlID, "aria-activedescendant", rowID)
 			} else {
 				session.removeProperty(htmlID, "data-current")
+				session.removeProperty(htmlID, "aria-activedescendant")
 			}
-			session.updateProperty(htmlID, "onkeydown", "tableViewRowKeyDownEvent(this, event)")
+			session.updateProperty(htmlID, "data-onkeydown", "tableViewRowKeyDownEvent(this, event)")
 
 		default: // NoneSelection
 			if tabIndex, ok := intProperty(table, TabIndex, session, -1); !ok || tabIndex < 0 {
 				session.removeProperty(htmlID, "tabindex")
 			}
 
-			for _, prop := range []string{"data-current", "onfocus", "onblur", "onkeydown", "data-selection"} {
+			for _, prop := range []string{"data-current", "aria-activedescendant", "data-onfocus", "data-onblur", "data-onkeydown", "data-selection"} {
 				session.removeProperty(htmlID, prop)
 			}
 		}
+		updateAriaProperty(table, Role)
 		updateInnerHTML(htmlID, session)
 
 	default:
@@ -988,6 +1016,13 @@ func (table *tableViewData) htmlTag() string {
 	return "table"
 }
 
+func (table *tableViewData) htmlRole() string {
+	if GetTableSelectionMode(table) != NoneSelection {
+		return "grid"
+	}
+	return ""
+}
+
 func tableViewRowID(view View, index int) string {
 	return fmt.Sprintf("%s-%d", view.htmlID(), index)
 }
@@ -1007,7 +1042,7 @@ func (table *tableViewData) htmlProperties(self View, buffer *strings.Builder) {
 	}
 
 	if selectionMode := GetTableSelectionMode(table); selectionMode != NoneSelection {
-		buffer.WriteString(` onfocus="tableViewFocusEvent(this, event)" onblur="tableViewBlurEvent(this, event)" data-focusitemstyle="`)
+		buffer.WriteString(` data-onfocus="tableViewFocusEvent(this, event)" data-onblur="tableViewBlurEvent(this, event)" data-focusitemstyle="`)
 		buffer.WriteString(tableViewCurrentStyle(table))
 		buffer.WriteString(`" data-bluritemstyle="`)
 		buffer.WriteString(tableViewCurrentInactiveStyle(table))
@@ -1017,18 +1052,24 @@ func (table *tableViewData) htmlProperties(self View, buffer *strings.Builder) {
 
 		switch selectionMode {
 		case RowSelection:
-			buffer.WriteString(` data-selection="row" onkeydown="tableViewRowKeyDownEvent(this, event)"`)
+			buffer.WriteString(` data-selection="row" data-onkeydown="tableViewRowKeyDownEvent(this, event)"`)
 			if current.Row >= 0 {
+				rowID := tableViewRowID(table, current.Row)
 				buffer.WriteString(` data-current="`)
-				buffer.WriteString(tableViewRowID(table, current.Row))
+				buffer.WriteString(rowID)
+				buffer.WriteString(`" aria-activedescendant="`)
+				buffer.WriteString(rowID)
 				buffer.WriteRune('"')
 			}
 
 		case CellSelection:
-			buffer.WriteString(` data-selection="cell" onkeydown="tableViewCellKeyDownEvent(this, event)"`)
+			buffer.WriteString(` data-selection="cell" data-onkeydown="tableViewCellKeyDownEvent(this, event)"`)
 			if current.Row >= 0 && current.Column >= 0 {
+				cellID := tableViewCellID(table, current.Row, current.Column)
 				buffer.WriteString(` data-current="`)
-				buffer.WriteString(tableViewCellID(table, current.Row, current.Column))
+				buffer.WriteString(cellID)
+				buffer.WriteString(`" aria-activedescendant="`)
+				buffer.WriteString(cellID)
 				buffer.WriteRune('"')
 			}
 		}
@@ -1128,10 +1169,10 @@ func (table *tableViewData) htmlSubviews(self View, buffer *strings.Builder) {
 					} else {
 						buffer.WriteString(tableViewCurrentInactiveStyle(table))
 					}
-					buffer.WriteRune('"')
+					buffer.WriteString(`" aria-selected="true"`)
 				}
 
-				buffer.WriteString(` onclick="tableRowClickEvent(this, event)"`)
+				buffer.WriteString(` data-onclick="tableRowClickEvent(this, event)"`)
 
 				if allowRowSelection != nil && !allowRowSelection.AllowRowSelection(row) {
 					buffer.WriteString(` inert`)
@@ -1222,7 +1263,10 @@ func (table *tableViewData) htmlSubviews(self View, buffer *strings.Builder) {
 					buffer.WriteRune('"')
 
 					if selectionMode == CellSelection {
-						buffer.WriteString(` onclick="tableCellClickEvent(this, event)"`)
+						if row == current.Row && column == current.Column {
+							buffer.WriteString(` aria-selected="true"`)
+						}
+						buffer.WriteString(` data-onclick="tableCellClickEvent(this, event)"`)
 						if allowCellSelection != nil && !allowCellSelection.AllowCellSelection(row, column) {
 							buffer.WriteString(` inert`)
 						}
@@ -1533,8 +1577,37 @@ func (table *tableViewData) cellPaddingFromStyle(style string) BoundsProperty {
 	return nil
 }
 
+// cellFormat returns the "format" property value for the column
+func (table *tableViewData) cellFormat(column int) string {
+	format := viewFormat(table)
+	if formats := strings.Split(format, ";"); len(formats) > 1 {
+		if column < len(formats) {
+			return strings.TrimSpace(formats[column])
+		}
+		return ""
+	}
+	return format
+}
+
 func (table *tableViewData) writeCellHtml(adapter TableAdapter, row, column int, buffer *strings.Builder) {
-	switch value := adapter.Cell(row, column).(type) {
+	cell := adapter.Cell(row, column)
+	if format := table.cellFormat(column); format != "" {
+		formatted := false
+		switch cell.(type) {
+		case float32, float64, time.Time, time.Duration:
+			formatted = true
+
+		default:
+			_, formatted = isInt(cell)
+		}
+
+		if formatted {
+			buffer.WriteString(textToHtml(table.Session().Formatter().Format(cell, format)))
+			return
+		}
+	}
+
+	switch value := cell.(type) {
 	case string:
 		buffer.WriteString(value)
 
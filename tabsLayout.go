@@ -560,7 +560,12 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 
 	if location != HiddenTabs {
 
-		buffer.WriteString(`<div class="`)
+		buffer.WriteString(`<div role="tablist" `)
+		switch location {
+		case LeftTabs, LeftListTabs, RightTabs, RightListTabs:
+			buffer.WriteString(`aria-orientation="vertical" `)
+		}
+		buffer.WriteString(`class="`)
 		buffer.WriteString(tabsLayout.tabBarStyle())
 		buffer.WriteString(`" style="display: flex;`)
 
@@ -626,17 +631,22 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 			buffer.WriteString(tabsLayoutID)
 			buffer.WriteByte('-')
 			buffer.WriteString(strconv.Itoa(n))
-			buffer.WriteString(`" class="`)
+			buffer.WriteString(`" role="tab" aria-controls="`)
+			buffer.WriteString(tabsLayoutID)
+			buffer.WriteString(`-page`)
+			buffer.WriteString(strconv.Itoa(n))
 			if n == current {
+				buffer.WriteString(`" aria-selected="true" tabindex="0" class="`)
 				buffer.WriteString(activeStyle)
 			} else {
+				buffer.WriteString(`" aria-selected="false" tabindex="-1" class="`)
 				buffer.WriteString(inactiveStyle)
 			}
-			buffer.WriteString(`" tabindex="0" onclick="tabClickEvent(this, '`)
+			buffer.WriteString(`" data-onclick="tabClickEvent(this, '`)
 			buffer.WriteString(tabsLayoutID)
 			buffer.WriteString(`', `)
 			buffer.WriteString(strconv.Itoa(n))
-			buffer.WriteString(`, event)" onkeydown="tabKeyClickEvent('`)
+			buffer.WriteString(`, event)" data-onkeydown="tabKeyClickEvent('`)
 			buffer.WriteString(tabsLayoutID)
 			buffer.WriteString(`', `)
 			buffer.WriteString(strconv.Itoa(n))
@@ -679,11 +689,13 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 				close = closeButton
 			}
 			if close {
-				buffer.WriteString(`<div class="ruiTabCloseButton" tabindex="0" onclick="tabCloseClickEvent(this, '`)
+				buffer.WriteString(`<div class="ruiTabCloseButton" role="button" aria-label="`)
+				buffer.WriteString(textToHtml(tabsLayout.closeButtonLabel()))
+				buffer.WriteString(`" tabindex="0" data-onclick="tabCloseClickEvent(this, '`)
 				buffer.WriteString(tabsLayoutID)
 				buffer.WriteString(`', `)
 				buffer.WriteString(strconv.Itoa(n))
-				buffer.WriteString(`, event)" onkeydown="tabCloseKeyClickEvent('`)
+				buffer.WriteString(`, event)" data-onkeydown="tabCloseKeyClickEvent('`)
 				buffer.WriteString(tabsLayoutID)
 				buffer.WriteString(`', `)
 				buffer.WriteString(strconv.Itoa(n))
@@ -714,6 +726,13 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 		buffer.WriteString(tabsLayoutID)
 		buffer.WriteString(`-page`)
 		buffer.WriteString(strconv.Itoa(n))
+		buffer.WriteString(`" role="tabpanel`)
+		if location != HiddenTabs {
+			buffer.WriteString(`" aria-labelledby="`)
+			buffer.WriteString(tabsLayoutID)
+			buffer.WriteByte('-')
+			buffer.WriteString(strconv.Itoa(n))
+		}
 
 		if current != n {
 			buffer.WriteString(`" style="display: grid; align-items: stretch; justify-items: stretch; visibility: hidden; `)
@@ -737,6 +756,11 @@ func (tabsLayout *tabsLayoutData) htmlSubviews(self View, buffer *strings.Builde
 	}
 }
 
+func (tabsLayout *tabsLayoutData) closeButtonLabel() string {
+	label, _ := tabsLayout.Session().GetString("Close")
+	return label
+}
+
 func (tabsLayout *tabsLayoutData) handleCommand(self View, command PropertyName, data DataObject) bool {
 	switch command {
 	case "tabClick":
@@ -50,8 +50,11 @@ func (textView *textViewData) propertyChanged(tag PropertyName) {
 		}
 		session.updateCSSProperty(textView.htmlID(), string(TextOverflow), "")
 
-	case NotTranslate:
+	case NotTranslate, Format:
 		updateInnerHTML(textView.htmlID(), textView.Session())
+		if tag == NotTranslate {
+			updateAriaProperty(textView, AriaLabel)
+		}
 
 	default:
 		textView.viewData.propertyChanged(tag)
@@ -101,7 +104,9 @@ func (textView *textViewData) setFunc(tag PropertyName, value any) []PropertyNam
 func (textView *textViewData) htmlSubviews(self View, buffer *strings.Builder) {
 	if value := textView.getRaw(Text); value != nil {
 		if text, ok := value.(string); ok && text != "" {
-			if !GetNotTranslate(textView) {
+			if format := viewFormat(textView); format != "" {
+				text = textView.session.Formatter().Format(text, format)
+			} else if !GetNotTranslate(textView) {
 				text, _ = textView.session.GetString(text)
 			}
 			buffer.WriteString(textToHtml(text))
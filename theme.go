@@ -2,6 +2,7 @@ package rui
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,6 +20,30 @@ const (
 	LandscapeMedia = 2
 )
 
+// Constants used as a values for [MediaStyleParams] members Contrast, Hover, and Pointer
+const (
+	// MediaContrastMore means that style apply if the user prefers more contrast
+	MediaContrastMore = 1
+
+	// MediaContrastLess means that style apply if the user prefers less contrast
+	MediaContrastLess = 2
+
+	// MediaHover means that style apply if the primary pointing device can hover over elements (a mouse)
+	MediaHover = 1
+
+	// MediaNoHover means that style apply if the primary pointing device can not hover over elements (a touch screen)
+	MediaNoHover = 2
+
+	// MediaPointerFine means that style apply if the primary pointing device is accurate (a mouse)
+	MediaPointerFine = 1
+
+	// MediaPointerCoarse means that style apply if the primary pointing device has limited accuracy (a finger)
+	MediaPointerCoarse = 2
+
+	// MediaPointerNone means that style apply if there is no pointing device
+	MediaPointerNone = 3
+)
+
 // MediaStyleParams define rules when particular style will be applied
 type MediaStyleParams struct {
 	// Orientation for which particular style will be applied
@@ -35,8 +60,38 @@ type MediaStyleParams struct {
 
 	// MaxHeight for which particular style will be applied
 	MaxHeight int
+
+	// Print is true if the style is applied when the page is printed (by default the style is applied on the screen)
+	Print bool
+
+	// ReducedMotion is true if the style is applied when the user prefers the reduced motion (minimum of animations)
+	ReducedMotion bool
+
+	// Contrast is the preferred contrast for which the style is applied: 0 (any), MediaContrastMore, or MediaContrastLess
+	Contrast int
+
+	// Hover is the hover capability for which the style is applied: 0 (any), MediaHover, or MediaNoHover
+	Hover int
+
+	// Pointer is the pointer accuracy for which the style is applied: 0 (any), MediaPointerFine,
+	// MediaPointerCoarse, or MediaPointerNone
+	Pointer int
+
+	// MinResolution is the minimal device pixel ratio for which the style is applied (0 - no limit)
+	MinResolution float64
+
+	// MaxResolution is the maximal device pixel ratio for which the style is applied (0 - no limit)
+	MaxResolution float64
+
+	// Container is the name of the query container (a view with the "container-name" property).
+	// If it is not empty then Orientation, MinWidth, MaxWidth, MinHeight, and MaxHeight are compared
+	// with the size of the container instead of the size of the window
+	Container string
 }
 
+// styleExtends is the name of the style field which lists (separated by commas) the styles extended by the style
+const styleExtends PropertyName = "extends"
+
 type mediaStyle struct {
 	MediaStyleParams
 	styles map[string]ViewStyle
@@ -44,6 +99,7 @@ type mediaStyle struct {
 
 type theme struct {
 	name           string
+	parent         string
 	constants      map[string]string
 	touchConstants map[string]string
 	colors         map[string]string
@@ -61,6 +117,14 @@ type Theme interface {
 	// Name returns a name of the theme
 	Name() string
 
+	// Parent returns the name of the parent theme ("" if the theme is based on the default theme).
+	// The parent theme is set by the "parent" field of the theme file
+	Parent() string
+
+	// SetParent sets the name of the parent theme. The values of the parent theme (and its parents)
+	// are used if they are not defined in this theme. "" or "default" means the default theme
+	SetParent(name string)
+
 	// Constant returns normal and touch theme constant value with specific tag
 	Constant(tag string) (string, string)
 
@@ -123,35 +187,175 @@ type Theme interface {
 	data() *theme
 }
 
-func (rule mediaStyle) cssText() string {
-	builder := allocStringBuilder()
-	defer freeStringBuilder(builder)
+// cssText returns the preludes of the "@media" and "@container" rules (each can be empty)
+func (rule mediaStyle) cssText() (string, string) {
+	media := allocStringBuilder()
+	defer freeStringBuilder(media)
+
+	container := allocStringBuilder()
+	defer freeStringBuilder(container)
+
+	sizes := media
+	if rule.Container != "" {
+		sizes = container
+	}
 
 	switch rule.Orientation {
 	case PortraitMedia:
-		builder.WriteString(" and (orientation: portrait)")
+		sizes.WriteString(" and (orientation: portrait)")
 
 	case LandscapeMedia:
-		builder.WriteString(" and (orientation: landscape)")
+		sizes.WriteString(" and (orientation: landscape)")
 	}
 
 	writeSize := func(tag string, minSize, maxSize int) {
 		if minSize != maxSize {
 			if minSize > 0 {
-				builder.WriteString(fmt.Sprintf(" and (min-%s: %d.001px)", tag, minSize))
+				sizes.WriteString(fmt.Sprintf(" and (min-%s: %d.001px)", tag, minSize))
 			}
 			if maxSize > 0 {
-				builder.WriteString(fmt.Sprintf(" and (max-%s: %dpx)", tag, maxSize))
+				sizes.WriteString(fmt.Sprintf(" and (max-%s: %dpx)", tag, maxSize))
 			}
 		} else if minSize > 0 {
-			builder.WriteString(fmt.Sprintf(" and (%s: %dpx)", tag, minSize))
+			sizes.WriteString(fmt.Sprintf(" and (%s: %dpx)", tag, minSize))
 		}
 	}
 
 	writeSize("width", rule.MinWidth, rule.MaxWidth)
 	writeSize("height", rule.MinHeight, rule.MaxHeight)
 
-	return builder.String()
+	if rule.ReducedMotion {
+		media.WriteString(" and (prefers-reduced-motion: reduce)")
+	}
+
+	switch rule.Contrast {
+	case MediaContrastMore:
+		media.WriteString(" and (prefers-contrast: more)")
+
+	case MediaContrastLess:
+		media.WriteString(" and (prefers-contrast: less)")
+	}
+
+	switch rule.Hover {
+	case MediaHover:
+		media.WriteString(" and (hover: hover)")
+
+	case MediaNoHover:
+		media.WriteString(" and (hover: none)")
+	}
+
+	switch rule.Pointer {
+	case MediaPointerFine:
+		media.WriteString(" and (pointer: fine)")
+
+	case MediaPointerCoarse:
+		media.WriteString(" and (pointer: coarse)")
+
+	case MediaPointerNone:
+		media.WriteString(" and (pointer: none)")
+	}
+
+	if rule.MinResolution > 0 {
+		media.WriteString(" and (min-resolution: " + strconv.FormatFloat(rule.MinResolution, 'f', -1, 64) + "dppx)")
+	}
+	if rule.MaxResolution > 0 {
+		media.WriteString(" and (max-resolution: " + strconv.FormatFloat(rule.MaxResolution, 'f', -1, 64) + "dppx)")
+	}
+
+	mediaText := ""
+	if rule.Print {
+		mediaText = "@media print" + media.String()
+	} else if media.Len() > 0 || rule.Container == "" {
+		mediaText = "@media screen" + media.String()
+	}
+
+	containerText := ""
+	if rule.Container != "" && container.Len() > 0 {
+		containerText = "@container " + rule.Container + " " + strings.TrimPrefix(container.String(), " and ")
+	}
+
+	return mediaText, containerText
+}
+
+// sectionName returns the name of the theme section ("styles:...") which describes the media rule
+func (rule mediaStyle) sectionName() string {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteString("styles")
+	switch rule.Orientation {
+	case PortraitMedia:
+		buffer.WriteString(":portrait")
+
+	case LandscapeMedia:
+		buffer.WriteString(":landscape")
+	}
+
+	writeRange := func(tag, min, max string) {
+		if min != "" {
+			buffer.WriteString(":" + tag + min + "-" + max)
+		} else if max != "" {
+			buffer.WriteString(":" + tag + max)
+		}
+	}
+
+	intText := func(n int) string {
+		if n > 0 {
+			return strconv.Itoa(n)
+		}
+		return ""
+	}
+
+	floatText := func(n float64) string {
+		if n > 0 {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+		return ""
+	}
+
+	writeRange("width", intText(rule.MinWidth), intText(rule.MaxWidth))
+	writeRange("height", intText(rule.MinHeight), intText(rule.MaxHeight))
+	writeRange("resolution", floatText(rule.MinResolution), floatText(rule.MaxResolution))
+
+	if rule.Print {
+		buffer.WriteString(":print")
+	}
+	if rule.ReducedMotion {
+		buffer.WriteString(":reduced-motion")
+	}
+
+	switch rule.Contrast {
+	case MediaContrastMore:
+		buffer.WriteString(":contrast-more")
+
+	case MediaContrastLess:
+		buffer.WriteString(":contrast-less")
+	}
+
+	switch rule.Hover {
+	case MediaHover:
+		buffer.WriteString(":hover")
+
+	case MediaNoHover:
+		buffer.WriteString(":no-hover")
+	}
+
+	switch rule.Pointer {
+	case MediaPointerFine:
+		buffer.WriteString(":pointer-fine")
+
+	case MediaPointerCoarse:
+		buffer.WriteString(":pointer-coarse")
+
+	case MediaPointerNone:
+		buffer.WriteString(":pointer-none")
+	}
+
+	if rule.Container != "" {
+		buffer.WriteString(":container-" + rule.Container)
+	}
+
+	return buffer.String()
 }
 
 func parseMediaRule(text string) (mediaStyle, bool) {
@@ -176,7 +380,62 @@ func parseMediaRule(text string) (mediaStyle, bool) {
 			}
 			rule.Orientation = LandscapeMedia
 
+		case "print":
+			rule.Print = true
+
+		case "reduced-motion":
+			rule.ReducedMotion = true
+
+		case "contrast-more":
+			rule.Contrast = MediaContrastMore
+
+		case "contrast-less":
+			rule.Contrast = MediaContrastLess
+
+		case "hover":
+			rule.Hover = MediaHover
+
+		case "no-hover":
+			rule.Hover = MediaNoHover
+
+		case "pointer-fine":
+			rule.Pointer = MediaPointerFine
+
+		case "pointer-coarse":
+			rule.Pointer = MediaPointerCoarse
+
+		case "pointer-none":
+			rule.Pointer = MediaPointerNone
+
 		default:
+			if name, ok := strings.CutPrefix(element, "container-"); ok {
+				if name == "" || rule.Container != "" {
+					ErrorLog(`Invalid "container" tag in the style section "` + text + `"`)
+					return rule, false
+				}
+				rule.Container = name
+				continue
+			}
+
+			if data, ok := strings.CutPrefix(element, "resolution"); ok {
+				var err error
+				minText, maxText, isRange := strings.Cut(data, "-")
+				if !isRange {
+					minText, maxText = "", data
+				}
+				if minText != "" {
+					rule.MinResolution, err = strconv.ParseFloat(minText, 64)
+				}
+				if err == nil && maxText != "" {
+					rule.MaxResolution, err = strconv.ParseFloat(maxText, 64)
+				}
+				if err != nil || (rule.MinResolution <= 0 && rule.MaxResolution <= 0) {
+					ErrorLog(`Invalid arguments of "resolution" tag in the style section "` + text + `"`)
+					return rule, false
+				}
+				continue
+			}
+
 			elementSize := func(name string) (int, int, bool, error) {
 				if strings.HasPrefix(element, name) {
 					var err error = nil
@@ -241,10 +500,22 @@ func parseMediaRule(text string) (mediaStyle, bool) {
 			}
 		}
 	}
+
+	if rule.Container != "" && rule.MinWidth == 0 && rule.MaxWidth == 0 && rule.MinHeight == 0 &&
+		rule.MaxHeight == 0 && rule.Orientation == DefaultMedia {
+		ErrorLog(`The container size is not specified in the style section "` + text + `"`)
+		return rule, false
+	}
 	return rule, true
 }
 
-var defaultTheme = NewTheme("")
+// builtinTheme creates the theme from the built-in defaultTheme.rui file
+func builtinTheme() Theme {
+	if theme, ok := CreateThemeFromText(defaultThemeText); ok {
+		return theme
+	}
+	return NewTheme("")
+}
 
 // NewTheme creates a new theme with specific name and return its interface.
 func NewTheme(name string) Theme {
@@ -256,9 +527,15 @@ func NewTheme(name string) Theme {
 
 // CreateThemeFromText creates a new theme from text and return its interface on success.
 func CreateThemeFromText(text string) (Theme, bool) {
+	return CreateThemeFromFile("", text)
+}
+
+// CreateThemeFromFile creates a new theme from the text of the file with the given name and return its interface on success.
+// The file name is used in the error messages.
+func CreateThemeFromFile(filename, text string) (Theme, bool) {
 	result := new(theme)
 	result.init()
-	ok := result.addText(text)
+	ok := result.addText(filename, text)
 	return result, ok
 }
 
@@ -277,6 +554,17 @@ func (theme *theme) Name() string {
 	return theme.name
 }
 
+func (theme *theme) Parent() string {
+	return theme.parent
+}
+
+func (theme *theme) SetParent(name string) {
+	if name == "default" {
+		name = ""
+	}
+	theme.parent = name
+}
+
 func (theme *theme) Constant(tag string) (string, string) {
 	return theme.constants[tag], theme.touchConstants[tag]
 }
@@ -382,19 +670,14 @@ func (theme *theme) RemoveStyle(tag string) {
 
 func (theme *theme) MediaStyle(tag string, params MediaStyleParams) ViewStyle {
 	for _, styles := range theme.mediaStyles {
-		if styles.Orientation == params.Orientation &&
-			styles.MaxWidth == params.MaxWidth &&
-			styles.MinWidth == params.MinWidth &&
-			styles.MaxHeight == params.MaxHeight &&
-			styles.MinHeight == params.MinHeight {
+		if styles.MediaStyleParams == params {
 			if style, ok := styles.styles[tag]; ok {
 				return style
 			}
 		}
 	}
 
-	if params.Orientation == 0 && params.MaxWidth == 0 && params.MinWidth == 0 &&
-		params.MaxHeight == 0 && params.MinHeight == 0 {
+	if params == (MediaStyleParams{}) {
 		return theme.style(tag)
 	}
 
@@ -415,18 +698,20 @@ func (theme *theme) SetMediaStyle(tag string, params MediaStyleParams, style Vie
 		params.MinHeight = 0
 	}
 
-	if params.Orientation == 0 && params.MaxWidth == 0 && params.MinWidth == 0 &&
-		params.MaxHeight == 0 && params.MinHeight == 0 {
+	if params.MaxResolution < 0 {
+		params.MaxResolution = 0
+	}
+	if params.MinResolution < 0 {
+		params.MinResolution = 0
+	}
+
+	if params == (MediaStyleParams{}) {
 		theme.SetStyle(tag, style)
 		return
 	}
 
 	for i, styles := range theme.mediaStyles {
-		if styles.Orientation == params.Orientation &&
-			styles.MaxWidth == params.MaxWidth &&
-			styles.MinWidth == params.MinWidth &&
-			styles.MaxHeight == params.MaxHeight &&
-			styles.MinHeight == params.MinHeight {
+		if styles.MediaStyleParams == params {
 			if style != nil {
 				theme.mediaStyles[i].styles[tag] = style
 				return
@@ -604,6 +889,10 @@ func (theme *theme) Append(anotherTheme Theme) {
 	}
 
 	another := anotherTheme.data()
+	if another.parent != "" {
+		theme.parent = another.parent
+	}
+
 	for tag, constant := range another.constants {
 		theme.constants[tag] = constant
 	}
@@ -635,11 +924,7 @@ func (theme *theme) Append(anotherTheme Theme) {
 	for _, anotherMedia := range another.mediaStyles {
 		exists := false
 		for _, media := range theme.mediaStyles {
-			if anotherMedia.MinHeight == media.MinHeight &&
-				anotherMedia.MaxHeight == media.MaxHeight &&
-				anotherMedia.MinWidth == media.MinWidth &&
-				anotherMedia.MaxWidth == media.MaxWidth &&
-				anotherMedia.Orientation == media.Orientation {
+			if anotherMedia.MediaStyleParams == media.MediaStyleParams {
 				for tag, style := range anotherMedia.styles {
 					media.styles[tag] = style
 				}
@@ -678,7 +963,7 @@ func (theme *theme) cssText(session Session) string {
 	}
 
 	for _, tag := range styleList(theme.styles) {
-		if style := theme.styles[tag]; style != nil {
+		if style := theme.style(tag); style != nil {
 			builder.startStyle(tag)
 			writeViewStyleCSS(style, &builder, session, false)
 			builder.endStyle()
@@ -686,26 +971,38 @@ func (theme *theme) cssText(session Session) string {
 	}
 
 	for _, media := range theme.mediaStyles {
-		builder.startMedia(media.cssText())
+		mediaRule, containerRule := media.cssText()
+		if mediaRule != "" {
+			builder.startMedia(mediaRule)
+		}
+		if containerRule != "" {
+			builder.startMedia(containerRule)
+		}
 		for _, tag := range styleList(media.styles) {
 			if style := media.styles[tag]; style != nil {
+				style = theme.extendedStyle(tag, style, []string{tag})
 				builder.startStyle(tag)
 				writeViewStyleCSS(style, &builder, session, false)
 				builder.endStyle()
 			}
 		}
-		builder.endMedia()
+		if containerRule != "" {
+			builder.endMedia()
+		}
+		if mediaRule != "" {
+			builder.endMedia()
+		}
 	}
 
-	return builder.finish()
+	return builder.finish() + pageCSS(theme, session)
 }
 
-func (theme *theme) addText(themeText string) bool {
+func (theme *theme) addText(filename, themeText string) bool {
 	if theme.constants == nil {
 		theme.init()
 	}
 
-	data, err := ParseDataText(themeText)
+	data, err := ParseDataFile(filename, themeText)
 	if err != nil {
 		ErrorLog(err.Error())
 		return false
@@ -738,6 +1035,12 @@ func (theme *theme) addText(themeText string) bool {
 					theme.name = text
 				}
 			}
+
+		case "parent":
+			if d.Type() == TextNode {
+				theme.SetParent(d.Text())
+			}
+
 		case "constants":
 			if d.Type() == ObjectNode {
 				if obj := d.Object(); obj != nil {
@@ -839,7 +1142,17 @@ func (theme *theme) addText(themeText string) bool {
 
 func (theme *theme) sortMediaStyles() {
 	if len(theme.mediaStyles) > 1 {
+		// the rules with preference or capability conditions are written after the size rules to override them
+		extra := func(rule mediaStyle) bool {
+			params := rule.MediaStyleParams
+			return params.Print || params.ReducedMotion || params.Contrast != 0 || params.Hover != 0 ||
+				params.Pointer != 0 || params.MinResolution > 0 || params.MaxResolution > 0 || params.Container != ""
+		}
+
 		sort.SliceStable(theme.mediaStyles, func(i, j int) bool {
+			if extra1, extra2 := extra(theme.mediaStyles[i]), extra(theme.mediaStyles[j]); extra1 != extra2 {
+				return extra2
+			}
 			if theme.mediaStyles[i].Orientation != theme.mediaStyles[j].Orientation {
 				return theme.mediaStyles[i].Orientation < theme.mediaStyles[j].Orientation
 			}
@@ -904,12 +1217,78 @@ func (theme *theme) image(tag string, darkUI bool) string {
 
 func (theme *theme) style(tag string) ViewStyle {
 	if style, ok := theme.styles[tag]; ok {
+		if _, ok := style.getRaw(styleExtends).(string); ok {
+			return theme.extendedStyle(tag, style, []string{tag})
+		}
 		return style
 	}
 
 	return nil
 }
 
+// extendedStyle returns the style which contains the properties of the styles listed in the "extends" field
+// (separated by commas) overridden by the properties of the style itself
+func (theme *theme) extendedStyle(tag string, style ViewStyle, prevTags []string) ViewStyle {
+	extends, ok := style.getRaw(styleExtends).(string)
+	if !ok {
+		return style
+	}
+
+	result := NewViewStyle(nil)
+	for _, parentTag := range strings.Split(extends, ",") {
+		parentTag = strings.TrimSpace(parentTag)
+		if parentTag == "" {
+			continue
+		}
+
+		if slices.Contains(prevTags, parentTag) {
+			ErrorLogF(`"%s" style extends itself`, tag)
+			continue
+		}
+
+		parent, ok := theme.styles[parentTag]
+		if !ok {
+			ErrorLogF(`"%s" style extends unknown "%s" style`, tag, parentTag)
+			continue
+		}
+
+		parent = theme.extendedStyle(parentTag, parent, append(slices.Clone(prevTags), parentTag))
+		for propTag, value := range parent.All() {
+			result.setRaw(propTag, value)
+		}
+	}
+
+	for propTag, value := range style.All() {
+		if propTag != styleExtends {
+			result.setRaw(propTag, value)
+		}
+	}
+	return result
+}
+
+// themeAncestors returns the parent themes of the theme starting from the most distant one
+func themeAncestors(theme Theme) []Theme {
+	result := []Theme{}
+	names := []string{theme.Name()}
+	for name := theme.Parent(); name != ""; {
+		if slices.Contains(names, name) {
+			ErrorLogF(`"%s" theme is a parent of itself`, name)
+			break
+		}
+
+		parent, ok := resources.theme(name)
+		if !ok {
+			ErrorLogF(`parent theme "%s" not found`, name)
+			break
+		}
+
+		result = append([]Theme{parent}, result...)
+		names = append(names, name)
+		name = parent.Parent()
+	}
+	return result
+}
+
 func (theme *theme) String() string {
 	buffer := allocStringBuilder()
 	defer freeStringBuilder(buffer)
@@ -960,6 +1339,12 @@ func (theme *theme) String() string {
 		buffer.WriteString(",\n")
 	}
 
+	if theme.parent != "" {
+		buffer.WriteString("\tparent = ")
+		writeString(theme.parent)
+		buffer.WriteString(",\n")
+	}
+
 	writeConstants("colors", theme.colors)
 	writeConstants("colors:dark", theme.darkColors)
 	writeConstants("images", theme.images)
@@ -1017,33 +1402,8 @@ func (theme *theme) String() string {
 			}
 			sort.Strings(tags)
 
-			buffer.WriteString("\tstyles")
-			switch media.Orientation {
-			case PortraitMedia:
-				buffer.WriteString(":portrait")
-
-			case LandscapeMedia:
-				buffer.WriteString(":landscape")
-			}
-
-			if media.MinWidth > 0 {
-				fmt.Fprintf(buffer, ":width%d-", media.MinWidth)
-				if media.MaxWidth > 0 {
-					buffer.WriteString(strconv.Itoa(media.MaxWidth))
-				}
-			} else if media.MaxWidth > 0 {
-				fmt.Fprintf(buffer, ":width%d", media.MaxWidth)
-			}
-
-			if media.MinHeight > 0 {
-				fmt.Fprintf(buffer, ":height%d-", media.MinHeight)
-				if media.MaxHeight > 0 {
-					buffer.WriteString(strconv.Itoa(media.MaxHeight))
-				}
-			} else if media.MaxHeight > 0 {
-				fmt.Fprintf(buffer, ":height%d", media.MaxHeight)
-			}
-
+			buffer.WriteString("\t")
+			buffer.WriteString(media.sectionName())
 			buffer.WriteString(" = [\n")
 
 			for _, tag := range tags {
@@ -0,0 +1,368 @@
+package rui
+
+import (
+	"slices"
+	"strings"
+)
+
+type themeEditorRow struct {
+	tag   string
+	color bool
+	edit  EditView
+	edit2 EditView
+}
+
+type themeEditorData struct {
+	session  Session
+	original Theme
+	edited   Theme
+	rows     []themeEditorRow
+	updating bool
+	// reload is true if the edited values are used by views, so the page must be reloaded (see commit)
+	reload  bool
+	onClose func()
+}
+
+// EnableThemeEditor registers the hotkey which shows/hides the theme editor (see ShowThemeEditor).
+// The theme editor is a development tool, do not enable it in production.
+//
+// Invoke DisableThemeEditor with the same key to remove the hotkey.
+func EnableThemeEditor(session Session, keyCode KeyCode, controlKeys ControlKeyMask) {
+	var popup Popup
+	session.SetHotKey(keyCode, controlKeys, func(session Session) {
+		if popup != nil {
+			popup.Dismiss()
+			return
+		}
+
+		editor := newThemeEditor(session)
+		editor.onClose = func() {
+			popup = nil
+		}
+		popup = editor.show()
+	})
+}
+
+// DisableThemeEditor removes the hotkey registered by EnableThemeEditor
+func DisableThemeEditor(session Session, keyCode KeyCode, controlKeys ControlKeyMask) {
+	session.SetHotKey(keyCode, controlKeys, nil)
+}
+
+// ShowThemeEditor shows the popup which lists the colors and the constants of the current theme
+// and allows to change them. Changes are applied to the styles of the session immediately, the views which use
+// the changed values are updated when the editing of the value is finished (Enter is pressed or the focus is lost).
+// The "Export" button shows the text of the edited theme in the .rui format (see Theme.String),
+// the "Reset" button restores the original theme.
+func ShowThemeEditor(session Session) Popup {
+	return newThemeEditor(session).show()
+}
+
+func newThemeEditor(session Session) *themeEditorData {
+	editor := &themeEditorData{
+		session:  session,
+		original: session.getCustomTheme(),
+	}
+	editor.edited = editor.newEditedTheme()
+	return editor
+}
+
+func (editor *themeEditorData) newEditedTheme() Theme {
+	if editor.original == nil {
+		return NewTheme("custom")
+	}
+
+	result := NewTheme(editor.original.Name())
+	result.Append(editor.original)
+	return result
+}
+
+func (editor *themeEditorData) show() Popup {
+	popup := NewPopup(editor.createContent(), Params{
+		Title:        "Theme editor",
+		CloseButton:  true,
+		OutsideClose: false,
+		MaxHeight:    Percent(90),
+		Buttons: []PopupButton{
+			{
+				Title:   "Export",
+				OnClick: editor.export,
+			},
+			{
+				Title:   "Reset",
+				OnClick: editor.reset,
+			},
+			{
+				Title: "Close",
+				Type:  CancelButton,
+				OnClick: func(popup Popup) {
+					popup.Dismiss()
+				},
+			},
+		},
+		DismissEvent: func() {
+			editor.commit()
+			if editor.onClose != nil {
+				editor.onClose()
+			}
+		},
+	})
+
+	popup.Show()
+	return popup
+}
+
+func (editor *themeEditorData) createContent() View {
+	session := editor.session
+	current := session.getCurrentTheme()
+	content := []View{}
+
+	addHeader := func(title, column1, column2 string) {
+		for _, text := range []string{title, column1, column2} {
+			content = append(content, NewTextView(session, Params{
+				Text:       text,
+				TextWeight: BoldFont,
+			}))
+		}
+	}
+
+	addRow := func(tag, value, value2 string, color bool) {
+		row := themeEditorRow{tag: tag, color: color}
+		newEdit := func(text string) EditView {
+			return NewEditView(session, Params{
+				Text:         text,
+				NotTranslate: true,
+				AriaLabel:    tag,
+				EditTextChangedEvent: func() {
+					editor.apply(row)
+				},
+				LostFocusEvent: editor.commit,
+				KeyDownEvent: func(event KeyEvent) {
+					if event.Code == EnterKey || event.Code == NumpadEnterKey {
+						editor.commit()
+					}
+				},
+			})
+		}
+		row.edit = newEdit(value)
+		row.edit2 = newEdit(value2)
+
+		editor.rows = append(editor.rows, row)
+		content = append(content, NewTextView(session, Params{
+			Text:         "@" + tag,
+			NotTranslate: true,
+		}), row.edit, row.edit2)
+	}
+
+	if tags := current.ColorTags(); len(tags) > 0 {
+		slices.Sort(tags)
+		addHeader("Colors", "Light", "Dark")
+		for _, tag := range tags {
+			color, dark := current.Color(tag)
+			addRow(tag, color, dark, true)
+		}
+	}
+
+	if tags := current.ConstantTags(); len(tags) > 0 {
+		slices.Sort(tags)
+		addHeader("Constants", "Normal", "Touch")
+		for _, tag := range tags {
+			value, touch := current.Constant(tag)
+			addRow(tag, value, touch, false)
+		}
+	}
+
+	return NewGridLayout(session, Params{
+		ID:            "ruiThemeEditor",
+		Content:       content,
+		CellWidth:     []SizeUnit{AutoSize(), Fr(1), Fr(1)},
+		GridRowGap:    Px(4),
+		GridColumnGap: Px(8),
+		Padding:       Px(8),
+		Overflow:      OverflowAuto,
+	})
+}
+
+// isValidThemeColor returns true if the text is a color value, a color constant (@name) or a color function
+func isValidThemeColor(text string) bool {
+	text = strings.TrimSpace(text)
+	if text == "" || text[0] == '@' || isColorFunction(text) {
+		return true
+	}
+	_, err := stringToColor(text)
+	return err == nil
+}
+
+// apply copies the values of the row to the edited theme and updates the styles of the session.
+// It is called on each change of the text, so the page is reloaded later by commit if the values are used by views
+func (editor *themeEditorData) apply(row themeEditorRow) {
+	if editor.updating {
+		return
+	}
+
+	value := strings.TrimSpace(GetText(row.edit))
+	value2 := strings.TrimSpace(GetText(row.edit2))
+	if value == "" {
+		// an empty value removes the tag from the edited theme, the inherited value is used
+		value2 = ""
+	}
+
+	var oldValue, oldValue2 string
+	if row.color {
+		if !isValidThemeColor(value) || !isValidThemeColor(value2) {
+			return
+		}
+		oldValue, oldValue2 = editor.edited.Color(row.tag)
+	} else {
+		oldValue, oldValue2 = editor.edited.Constant(row.tag)
+	}
+
+	if oldValue == value && oldValue2 == value2 {
+		return
+	}
+
+	if row.color {
+		editor.edited.SetColor(row.tag, value, value2)
+	} else {
+		editor.edited.SetConstant(row.tag, value, value2)
+	}
+
+	if editor.usedByViews(row.tag) {
+		editor.reload = true
+	}
+	editor.session.updateCustomTheme(editor.edited)
+}
+
+// commit reloads the page if the applied values are used by views. It is called when the editing of a value
+// is finished (the edit loses the focus or Enter is pressed) and when the editor is closed
+func (editor *themeEditorData) commit() {
+	if editor.reload {
+		editor.reload = false
+		editor.session.setCustomTheme(editor.edited)
+	}
+}
+
+// themeRenderTags are the theme values which are used while the HTML of views is created
+// (the images of checkboxes and radio buttons)
+var themeRenderTags = []string{"ruiBackgroundColor", "ruiDisabledTextColor", "ruiHighlightColor", "ruiHighlightTextColor"}
+
+// usedByViews returns true if the theme value is used by the views of the root view tree or the popups directly
+// or through other constants and colors. Otherwise the change of the value affects only the styles
+func (editor *themeEditorData) usedByViews(tag string) bool {
+	current := editor.session.getCurrentTheme()
+	tags := map[string]bool{tag: true}
+
+	// the constants and the colors which refer to the tag
+	for changed := true; changed; {
+		changed = false
+		for _, color := range []bool{true, false} {
+			list := current.ConstantTags()
+			if color {
+				list = current.ColorTags()
+			}
+
+			for _, name := range list {
+				if !tags[name] {
+					var value, value2 string
+					if color {
+						value, value2 = current.Color(name)
+					} else {
+						value, value2 = current.Constant(name)
+					}
+					if refersToThemeTag(value, tags) || refersToThemeTag(value2, tags) {
+						tags[name] = true
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	for _, name := range themeRenderTags {
+		if tags[name] {
+			return true
+		}
+	}
+
+	var viewUses func(view View) bool
+	viewUses = func(view View) bool {
+		for _, propTag := range view.AllTags() {
+			if propTag != Content && refersToThemeTag(propertyValueToString(propTag, view.getRaw(propTag), ""), tags) {
+				return true
+			}
+		}
+
+		if container, ok := view.(ParentView); ok {
+			for _, child := range container.Views() {
+				if child != nil && viewUses(child) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if root := editor.session.RootView(); root != nil && viewUses(root) {
+		return true
+	}
+
+	manager := editor.session.popupManager()
+	manager.mutex.Lock()
+	popups := slices.Clone(manager.popups)
+	manager.mutex.Unlock()
+
+	for _, popup := range popups {
+		if layer := popup.layer(); layer != nil && viewUses(layer) {
+			return true
+		}
+	}
+	return false
+}
+
+// refersToThemeTag returns true if the text contains a reference (@name) to one of the tags
+func refersToThemeTag(text string, tags map[string]bool) bool {
+	for _, token := range strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune(", :;|/\t\n()\"'", r)
+	}) {
+		if len(token) > 1 && token[0] == '@' && tags[token[1:]] {
+			return true
+		}
+	}
+	return false
+}
+
+func (editor *themeEditorData) export(Popup) {
+	session := editor.session
+	NewPopup(NewEditView(session, Params{
+		Text:         editor.edited.String(),
+		EditViewType: MultiLineText,
+		ReadOnly:     true,
+		Spellcheck:   false,
+		Width:        Em(40),
+		Height:       Em(30),
+	}), Params{
+		Title:        "Theme",
+		CloseButton:  true,
+		OutsideClose: true,
+	}).Show()
+}
+
+func (editor *themeEditorData) reset(Popup) {
+	session := editor.session
+	editor.edited = editor.newEditedTheme()
+	editor.reload = false
+	session.setCustomTheme(editor.original)
+
+	editor.updating = true
+	current := session.getCurrentTheme()
+	for _, row := range editor.rows {
+		var value, value2 string
+		if row.color {
+			value, value2 = current.Color(row.tag)
+		} else {
+			value, value2 = current.Constant(row.tag)
+		}
+		row.edit.Set(Text, value)
+		row.edit2.Set(Text, value2)
+	}
+	editor.updating = false
+}
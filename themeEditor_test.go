@@ -0,0 +1,97 @@
+package rui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestThemeEditor(t *testing.T) {
+	session := newSession(nil, 0, "", nil).(*sessionData)
+
+	editor := newThemeEditor(session)
+	editor.createContent()
+
+	var row themeEditorRow
+	for _, r := range editor.rows {
+		if r.color && r.tag == "ruiTextColor" {
+			row = r
+		}
+	}
+	if row.edit == nil {
+		t.Fatal(`"ruiTextColor" row not found`)
+	}
+
+	editor.updating = true
+	row.edit.Set(Text, "#FF112233")
+	row.edit2.Set(Text, "")
+	editor.updating = false
+	editor.apply(row)
+
+	if color, ok := session.Color("ruiTextColor"); !ok || color != 0xFF112233 {
+		t.Errorf(`Color("ruiTextColor") = %s, expected #FF112233`, color.String())
+	}
+	if text := editor.edited.String(); !strings.Contains(text, "ruiTextColor") {
+		t.Errorf("exported theme does not contain the edited color:\n%s", text)
+	}
+
+	editor.reset(nil)
+	if session.customTheme != nil {
+		t.Error("reset does not restore the original theme")
+	}
+}
+
+func TestThemeEditorUpdate(t *testing.T) {
+	createTestLog(t, false)
+
+	session := newSession(nil, 0, "", nil).(*sessionData)
+	session.rootView = NewTextView(session, Params{Text: "Hello", TextColor: "@ruiTextColor"})
+
+	messages := []string{}
+	bridge := createHttpBridge(httptest.NewRequest(http.MethodPost, "/", nil))
+	bridge.writeMessage = func(text string) bool {
+		messages = append(messages, text)
+		return true
+	}
+	session.bridge = bridge
+
+	editor := newThemeEditor(session)
+	editor.createContent()
+
+	edit := func(tag, value string) string {
+		messages = messages[:0]
+		for _, row := range editor.rows {
+			if row.tag == tag {
+				editor.updating = true
+				row.edit.Set(Text, value)
+				editor.updating = false
+				editor.apply(row)
+				return strings.Join(messages, "\n")
+			}
+		}
+		t.Fatalf(`"%s" row not found`, tag)
+		return ""
+	}
+
+	if text := edit("ruiButtonRadius", "6px"); !strings.Contains(text, "setStyles") || strings.Contains(text, "ruiRootView") {
+		t.Errorf("the change of the style constant must update only the styles:\n%s", text)
+	}
+
+	if text := edit("ruiTextColor", "#FF112233"); !strings.Contains(text, "setStyles") || strings.Contains(text, "ruiRootView") {
+		t.Errorf("the root view must not be reloaded while the value is edited:\n%s", text)
+	}
+
+	messages = messages[:0]
+	editor.commit()
+	if text := strings.Join(messages, "\n"); !strings.Contains(text, "ruiRootView") {
+		t.Errorf("the change of the color used by the view must reload the root view:\n%s", text)
+	}
+
+	// the views of the popups are checked too
+	popup := NewPopup(NewTextView(session, Params{Text: "Popup", TextColor: "@ruiDisabledTextColor"}), nil)
+	session.popupManager().popups = append(session.popupManager().popups, popup)
+	if !editor.usedByViews("ruiDisabledTextColor") {
+		t.Error("the color used by the popup is not found")
+	}
+}
@@ -0,0 +1,116 @@
+package rui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestThemeInheritance(t *testing.T) {
+	createTestLog(t, false)
+
+	base, _ := CreateThemeFromText(`theme {
+		name = brandBase,
+		colors = _{ brandColor = #FF336699, textAccent = @brandColor },
+		styles = [ brandButton { padding = 8px, text-color = @brandColor } ],
+	}`)
+	brand, _ := CreateThemeFromText(`theme {
+		name = brand,
+		parent = brandBase,
+		colors = _{
+			light = "lighten(@brandColor, 20%)",
+			dark = "darken(#FF808080, 50%)",
+			half = "alpha(@textAccent, 50%)",
+			mixed = "mix(#FFFFFFFF, #FF000000, 25%)",
+		},
+		styles = [ bigButton { extends = brandButton, padding = 16px } ],
+	}`)
+	resources.themes["brandBase"] = base
+	defer delete(resources.themes, "brandBase")
+
+	session := newSession(nil, 0, "", nil).(*sessionData)
+	session.customTheme = brand
+
+	for _, test := range []struct {
+		tag   string
+		color Color
+	}{
+		{"brandColor", 0xFF336699},
+		{"light", 0xFF6699CC},
+		{"dark", 0xFF000000},
+		{"half", 0x80336699},
+		{"mixed", 0xFF404040},
+	} {
+		if color, ok := session.Color(test.tag); !ok || color != test.color {
+			t.Errorf(`Color("%s") = %s, expected %s`, test.tag, color.String(), test.color.String())
+		}
+	}
+
+	if padding := session.styleProperty("bigButton", Padding); padding == nil {
+		t.Error(`"bigButton" style has no padding`)
+	} else if text := fmt.Sprint(padding); !strings.Contains(text, "16px") {
+		t.Errorf(`"bigButton" style padding = %s, expected 16px`, text)
+	}
+	if textColor := session.styleProperty("bigButton", TextColor); textColor == nil {
+		t.Error(`"bigButton" style does not inherit text-color`)
+	}
+}
+
+func TestMediaStyleExtends(t *testing.T) {
+	createTestLog(t, false)
+
+	mediaTheme, ok := CreateThemeFromText(`theme {
+		styles = [ baseButton { text-color = #FF0000FF } ],
+		styles:portrait = [ portraitButton { extends = baseButton, padding = 4px } ],
+	}`)
+	if !ok {
+		t.Fatal("CreateThemeFromText failed")
+	}
+
+	session := newSession(nil, 0, "", nil).(*sessionData)
+	css := mediaTheme.(*theme).cssText(session)
+	index := strings.Index(css, ".portraitButton")
+	if index < 0 {
+		t.Fatalf("the media style is not written:\n%s", css)
+	}
+
+	style := css[index:]
+	style = style[:strings.IndexRune(style, '}')]
+	if !strings.Contains(style, "color:") || !strings.Contains(style, "padding:") || strings.Contains(style, "extends") {
+		t.Errorf("the media style does not extend the base style: %s", style)
+	}
+}
+
+func TestMediaStyleRules(t *testing.T) {
+	createTestLog(t, false)
+
+	tests := []struct{ section, media, container string }{
+		{"styles:landscape:width600-", "@media screen and (orientation: landscape) and (min-width: 600.001px)", ""},
+		{"styles:reduced-motion:contrast-more", "@media screen and (prefers-reduced-motion: reduce) and (prefers-contrast: more)", ""},
+		{"styles:hover:pointer-fine", "@media screen and (hover: hover) and (pointer: fine)", ""},
+		{"styles:resolution2-", "@media screen and (min-resolution: 2dppx)", ""},
+		{"styles:print", "@media print", ""},
+		{"styles:width400:container-sidebar", "", "@container sidebar (max-width: 400px)"},
+		{"styles:width400:no-hover:container-sidebar", "@media screen and (hover: none)", "@container sidebar (max-width: 400px)"},
+	}
+
+	for _, test := range tests {
+		rule, ok := parseMediaRule(test.section)
+		if !ok {
+			t.Errorf(`parseMediaRule("%s") failed`, test.section)
+			continue
+		}
+
+		if media, container := rule.cssText(); media != test.media || container != test.container {
+			t.Errorf(`"%s": cssText() = "%s", "%s", expected "%s", "%s"`, test.section, media, container, test.media, test.container)
+		}
+		if name := rule.sectionName(); name != test.section {
+			t.Errorf(`sectionName() = "%s", expected "%s"`, name, test.section)
+		}
+	}
+
+	createTestLog(t, true)
+	if _, ok := parseMediaRule("styles:container-sidebar"); ok {
+		t.Error(`parseMediaRule("styles:container-sidebar") must fail without a size`)
+	}
+}
@@ -315,9 +315,9 @@ func (picker *timePickerData) htmlProperties(self View, buffer *strings.Builder)
 	buffer.WriteString(GetTimePickerValue(picker).Format(timeFormat))
 	buffer.WriteByte('"')
 
-	buffer.WriteString(` oninput="editViewInputEvent(this)"`)
+	buffer.WriteString(` data-oninput="editViewInputEvent(this)"`)
 	if picker.getRaw(ClickEvent) == nil {
-		buffer.WriteString(` onclick="stopEventPropagation(this, event)"`)
+		buffer.WriteString(` data-onclick="stopEventPropagation(this, event)"`)
 	}
 
 	dataListHtmlProperties(picker, buffer)
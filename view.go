@@ -94,6 +94,7 @@ type View interface {
 	handleCommand(self View, command PropertyName, data DataObject) bool
 	htmlClass() string
 	htmlTag() string
+	htmlRole() string
 	closeHTMLTag() bool
 	htmlID() string
 	parentHTMLID() string
@@ -277,6 +278,9 @@ func (view *viewData) Set(tag PropertyName, value any) bool {
 
 	tag = view.normalize(tag)
 	changedTags := view.set(tag, value)
+	if changedTags == nil && view.session != nil {
+		view.session.setLastError(invalidPropertyValueText(tag, value))
+	}
 
 	if view.created && len(changedTags) > 0 {
 		for _, tag := range changedTags {
@@ -854,13 +858,19 @@ func (view *viewData) propertyChanged(tag PropertyName) {
 			session.updateCSSProperty(htmlID, `column-span`, `none`)
 		}
 
+	case Role, AriaLabel, AriaLabelledBy, AriaDescribedBy, AriaLive, AriaHidden, AriaModal:
+		updateAriaProperty(view, tag)
+
+	case NotTranslate:
+		updateAriaProperty(view, AriaLabel)
+
 	case Tooltip:
 		if tooltip := GetTooltip(view); tooltip == "" {
 			session.removeProperty(htmlID, "data-tooltip")
 		} else {
 			session.updateProperty(htmlID, "data-tooltip", tooltip)
-			session.updateProperty(htmlID, "onmouseenter", "mouseEnterEvent(this, event)")
-			session.updateProperty(htmlID, "onmouseleave", "mouseLeaveEvent(this, event)")
+			session.updateProperty(htmlID, "data-onmouseenter", "mouseEnterEvent(this, event)")
+			session.updateProperty(htmlID, "data-onmouseleave", "mouseLeaveEvent(this, event)")
 		}
 
 	case PerspectiveOriginX, PerspectiveOriginY:
@@ -901,23 +911,23 @@ func (view *viewData) propertyChanged(tag PropertyName) {
 
 	case DragStartEvent:
 		if view.getRaw(DragStartEvent) != nil || view.getRaw(DragData) != nil {
-			session.updateProperty(htmlID, "ondragstart", "dragStartEvent(this, event)")
+			session.updateProperty(htmlID, "data-ondragstart", "dragStartEvent(this, event)")
 		} else {
-			session.removeProperty(htmlID, "ondragstart")
+			session.removeProperty(htmlID, "data-ondragstart")
 		}
 
 	case DropEvent:
 		if view.getRaw(DropEvent) != nil {
-			session.updateProperty(htmlID, "ondrop", "dropEvent(this, event)")
-			session.updateProperty(htmlID, "ondragover", "dragOverEvent(this, event)")
+			session.updateProperty(htmlID, "data-ondrop", "dropEvent(this, event)")
+			session.updateProperty(htmlID, "data-ondragover", "dragOverEvent(this, event)")
 			if view.getRaw(DragOverEvent) != nil {
 				session.updateProperty(htmlID, "data-drag-over", "1")
 			} else {
 				session.removeProperty(htmlID, "data-drag-over")
 			}
 		} else {
-			session.removeProperty(htmlID, "ondrop")
-			session.removeProperty(htmlID, "ondragover")
+			session.removeProperty(htmlID, "data-ondrop")
+			session.removeProperty(htmlID, "data-ondragover")
 		}
 
 	case DragOverEvent:
@@ -931,12 +941,12 @@ func (view *viewData) propertyChanged(tag PropertyName) {
 		if data := base64DragData(view); data != "" {
 			session.updateProperty(htmlID, "draggable", "true")
 			session.updateProperty(htmlID, "data-drag", data)
-			session.updateProperty(htmlID, "ondragstart", "dragStartEvent(this, event)")
+			session.updateProperty(htmlID, "data-ondragstart", "dragStartEvent(this, event)")
 		} else {
 			session.removeProperty(htmlID, "draggable")
 			session.removeProperty(htmlID, "data-drag")
 			if view.getRaw(DragStartEvent) == nil {
-				session.removeProperty(htmlID, "ondragstart")
+				session.removeProperty(htmlID, "data-ondragstart")
 			}
 		}
 
@@ -994,6 +1004,19 @@ func (view *viewData) propertyChanged(tag PropertyName) {
 	case DataList:
 		updateInnerHTML(htmlID, session)
 
+	case ContainerName:
+		if name, ok := stringProperty(view, ContainerName, session); ok && name != "" {
+			session.updateCSSProperty(htmlID, string(ContainerName), name)
+			if view.getRaw(ContainerType) == nil {
+				session.updateCSSProperty(htmlID, string(ContainerType), "inline-size")
+			}
+		} else {
+			session.updateCSSProperty(htmlID, string(ContainerName), "")
+			if view.getRaw(ContainerType) == nil {
+				session.updateCSSProperty(htmlID, string(ContainerType), "")
+			}
+		}
+
 	case Opacity:
 		if f, ok := floatTextProperty(view, Opacity, session, 0); ok {
 			session.updateCSSProperty(htmlID, string(Opacity), f)
@@ -1042,6 +1065,10 @@ func (view *viewData) propertyChanged(tag PropertyName) {
 	}
 }
 
+func (view *viewData) htmlRole() string {
+	return ""
+}
+
 func (view *viewData) htmlTag() string {
 	if semantics := GetSemantics(view); semantics > DefaultSemantics {
 		values := enumProperties[Semantics].cssValues
@@ -1136,10 +1163,12 @@ func viewHTML(view View, buffer *strings.Builder, htmlTag string) {
 	if tooltip := GetTooltip(view); tooltip != "" {
 		buffer.WriteString(`data-tooltip=" `)
 		buffer.WriteString(tooltip)
-		buffer.WriteString(`" onmouseenter="mouseEnterEvent(this, event)" onmouseleave="mouseLeaveEvent(this, event)" `)
+		buffer.WriteString(`" data-onmouseenter="mouseEnterEvent(this, event)" data-onmouseleave="mouseLeaveEvent(this, event)" `)
 	}
 
-	buffer.WriteString(`onscroll="scrollEvent(this, event)" `)
+	ariaHtml(view, buffer)
+
+	buffer.WriteString(`data-onscroll="scrollEvent(this, event)" `)
 
 	focusEventsHtml(view, buffer)
 	keyEventsHtml(view, buffer)
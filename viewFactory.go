@@ -86,6 +86,7 @@ func CreateViewFromObject(session Session, object DataObject, binding any) View
 	creator, ok := viewCreators()[strings.ToLower(tag)]
 	if !ok {
 		ErrorLog(`Unknown view type "` + tag + `"`)
+		session.addValidationError(DataPositionOf(object), tag, "unknown view type")
 		return nil
 	}
 
@@ -101,7 +102,11 @@ func CreateViewFromObject(session Session, object DataObject, binding any) View
 			return nil
 		}
 	}
-	parseProperties(view, object)
+	if session.isValidation() {
+		validateViewProperties(view, tag, object)
+	} else {
+		parseProperties(view, object)
+	}
 	if binding != nil {
 		view.setRaw(Binding, binding)
 		if listener, ok := binding.(ViewCreateListener); ok {
@@ -150,7 +155,7 @@ func CreateViewFromResources(session Session, name string, binding ...any) View
 
 	createEmbed := func(fs *embed.FS, path string) View {
 		if data, err := fs.ReadFile(path); err == nil {
-			data, err := ParseDataText(string(data))
+			data, err := ParseDataFile(path, string(data))
 			if err == nil {
 				return CreateViewFromObject(session, data, b)
 			}
@@ -180,8 +185,9 @@ func CreateViewFromResources(session Session, name string, binding ...any) View
 	}
 
 	if resources.path != "" {
-		if data, err := os.ReadFile(resources.path + viewDir + "/" + name); err == nil {
-			data, err := ParseDataText(string(data))
+		path := resources.path + viewDir + "/" + name
+		if data, err := os.ReadFile(path); err == nil {
+			data, err := ParseDataFile(path, string(data))
 			if err != nil {
 				ErrorLog(err.Error())
 			} else {
@@ -301,11 +301,19 @@ func writeViewStyleCSS(style Properties, builder cssBuilder, session Session, ig
 		builder.add(`font-family`, font)
 	}
 
+	if name, ok := stringProperty(style, ContainerName, session); ok && name != "" {
+		builder.add(string(ContainerName), name)
+		if style.getRaw(ContainerType) == nil {
+			builder.add(string(ContainerType), "inline-size")
+		}
+	}
+
 	writingMode := 0
 	for _, tag := range []PropertyName{
 		Overflow, TextAlign, TextTransform, TextWeight, TextLineStyle, WritingMode, TextDirection,
 		VerticalTextOrientation, CellVerticalAlign, CellHorizontalAlign, GridAutoFlow, Cursor,
-		WhiteSpace, WordBreak, TextOverflow, Float, TableVerticalAlign, Resize, MixBlendMode, BackgroundBlendMode} {
+		WhiteSpace, WordBreak, TextOverflow, Float, TableVerticalAlign, Resize, MixBlendMode, BackgroundBlendMode,
+		ContainerType} {
 
 		if data, ok := enumProperties[tag]; ok {
 			if tag != VerticalTextOrientation || (writingMode != VerticalLeftToRight && writingMode != VerticalRightToLeft) {
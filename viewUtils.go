@@ -949,3 +949,28 @@ func GetTooltip(view View, subviewID ...string) string {
 
 	return ""
 }
+
+// GetFormat returns a value of the "format" property of the subview.
+//
+// The second argument (subviewID) specifies the path to the child element whose value needs to be returned.
+// If it is not specified then a value from the first argument (view) is returned.
+func GetFormat(view View, subviewID ...string) string {
+	if view = getSubview(view, subviewID); view != nil {
+		if value := view.Get(Format); value != nil {
+			if text, ok := value.(string); ok {
+				return text
+			}
+		}
+	}
+
+	return ""
+}
+
+// viewFormat returns the "format" property value translated to the session language
+func viewFormat(view View) string {
+	format := GetFormat(view)
+	if format != "" && !GetNotTranslate(view) {
+		format, _ = view.Session().GetString(format)
+	}
+	return format
+}
@@ -0,0 +1,163 @@
+package rui
+
+import (
+	"strings"
+	"sync"
+)
+
+//go:generate go run gen_known_properties.go
+
+type tagNormalizer interface {
+	normalizeTag(tag PropertyName) PropertyName
+}
+
+func (properties *propertyList) normalizeTag(tag PropertyName) PropertyName {
+	if properties.normalize != nil {
+		return properties.normalize(tag)
+	}
+	return defaultNormalize(tag)
+}
+
+// ValidateViewObject checks the description of a view (including all child views) and returns the list of found errors:
+// unknown view types, unknown properties and invalid property values. The result is nil if no errors are found.
+//
+// Views are created in a temporary session that is not connected to any client,
+// so the function can be used to check .rui view resources without starting the application (e.g. in CI).
+// Properties of views that are created by functions registered with RegisterViewCreator are not checked for names.
+func ValidateViewObject(object DataObject) []*DataError {
+	if object == nil {
+		return nil
+	}
+
+	session := newSession(nil, 0, "", nil)
+	session.startValidation()
+	CreateViewFromObject(session, object, nil)
+	return session.finishValidation()
+}
+
+// ValidateViewText parses the text of a view description and checks it (see ValidateViewObject).
+// The filename is used only for the positions of errors and may be empty.
+func ValidateViewText(filename, text string) []*DataError {
+	object, err := ParseDataFile(filename, text)
+	if err != nil {
+		if dataErr, ok := err.(*DataError); ok {
+			return []*DataError{dataErr}
+		}
+		return []*DataError{{Position: DataPosition{File: filename}, Message: err.Error()}}
+	}
+	return ValidateViewObject(object)
+}
+
+func (session *sessionData) startValidation() {
+	session.validationErrors = []*DataError{}
+}
+
+func (session *sessionData) finishValidation() []*DataError {
+	result := session.validationErrors
+	session.validationErrors = nil
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (session *sessionData) isValidation() bool {
+	return session.validationErrors != nil
+}
+
+func (session *sessionData) addValidationError(position DataPosition, tag, message string) {
+	if session.validationErrors != nil {
+		session.validationErrors = append(session.validationErrors, &DataError{
+			Position: position,
+			Tag:      tag,
+			Message:  message,
+		})
+	}
+}
+
+// knownProperties returns the set of the property names which are checked by the validation:
+// the generated list of the property constants (see gen_known_properties.go) and the names
+// of the property tables
+var knownProperties = sync.OnceValue(func() map[PropertyName]bool {
+	result := map[PropertyName]bool{}
+	for _, list := range [][]PropertyName{knownPropertyNames, colorProperties, angleProperties,
+		boolProperties, intProperties, ariaProperties} {
+		for _, name := range list {
+			result[name] = true
+		}
+	}
+
+	for name := range sizeProperties {
+		result[name] = true
+	}
+	for name := range enumProperties {
+		result[name] = true
+	}
+	for name := range floatProperties {
+		result[name] = true
+	}
+	for name := range eventJsFunc {
+		result[name] = true
+	}
+	return result
+})
+
+func isKnownPropertyName(tag PropertyName) bool {
+	return knownProperties()[tag]
+}
+
+func validateViewProperties(view View, viewTag string, object DataObject) {
+	session := view.Session()
+	checkNames := false
+	for name := range systemViewCreators {
+		if strings.EqualFold(name, viewTag) {
+			checkNames = true
+			break
+		}
+	}
+
+	normalizer, _ := view.(tagNormalizer)
+
+	for node := range object.Properties() {
+		tag := PropertyName(node.Tag())
+		if normalizer != nil {
+			tag = normalizer.normalizeTag(tag)
+		} else {
+			tag = normalizeViewTag(tag)
+		}
+
+		if checkNames && !isKnownPropertyName(tag) {
+			session.addValidationError(DataPositionOf(node), node.Tag(), "unknown property")
+			continue
+		}
+
+		var value any
+		switch node.Type() {
+		case TextNode:
+			value = node.Text()
+
+		case ObjectNode:
+			value = node.Object()
+
+		case ArrayNode:
+			switch node.ArraySize() {
+			case 0:
+				continue
+
+			case 1:
+				if v := node.ArrayElement(0); v.IsObject() {
+					value = v.Object()
+				} else {
+					value = v.Value()
+				}
+
+			default:
+				value = node.Array()
+			}
+		}
+
+		if tag := PropertyName(node.Tag()); !view.Set(tag, value) {
+			session.addValidationError(DataPositionOf(node), node.Tag(), invalidPropertyValueText(tag, value))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package rui
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateViewText(t *testing.T) {
+	createTestLog(t, true)
+
+	text := `ListLayout {
+	id = list,
+	width = 100%,
+	unknown-property = 1,
+	content = [
+		TextView { text = "Hello" },
+		UnknownView { },
+		Button { content = "Ok", height = abc },
+	]
+}`
+
+	errors := ValidateViewText("view.rui", text)
+	if len(errors) != 3 {
+		t.Fatalf("ValidateViewText result: %v", errors)
+	}
+
+	expected := []struct {
+		tag  string
+		line int
+	}{
+		{"unknown-property", 4},
+		{"UnknownView", 7},
+		{"height", 8},
+	}
+
+	for i, err := range errors {
+		if err.Tag != expected[i].tag || err.Position.Line != expected[i].line {
+			t.Errorf("ValidateViewText error %d: %s", i, err.Error())
+		}
+	}
+
+	if errors[2].Message != `Invalid value "abc" of "height" property` {
+		t.Errorf(`ValidateViewText message of "height": %s`, errors[2].Message)
+	}
+
+	if errors := ValidateViewText("view.rui", `View { width = 10px }`); errors != nil {
+		t.Errorf("ValidateViewText result: %v", errors)
+	}
+}
+
+// TestKnownPropertyNames checks that knownProperties.go is regenerated after adding a property constant
+func TestKnownPropertyNames(t *testing.T) {
+	files, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileSet := token.NewFileSet()
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || strings.HasPrefix(name, "gen_") {
+			continue
+		}
+
+		source, err := parser.ParseFile(fileSet, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, decl := range source.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.CONST {
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.ValueSpec)
+					if ident, ok := spec.Type.(*ast.Ident); !ok || ident.Name != "PropertyName" {
+						continue
+					}
+					for i, ident := range spec.Names {
+						if !ident.IsExported() || i >= len(spec.Values) {
+							continue
+						}
+						if value, ok := spec.Values[i].(*ast.BasicLit); ok {
+							tag := PropertyName(strings.Trim(value.Value, "\"`"))
+							if !isKnownPropertyName(tag) {
+								t.Errorf(`%s (%s) is not in knownProperties.go, run "go generate"`, ident.Name, name)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -5,7 +5,6 @@ package rui
 import (
 	"fmt"
 	"strconv"
-	"strings"
 	"syscall/js"
 )
 
@@ -26,9 +25,9 @@ func createWasmBridge(close chan DataObject) bridge {
 }
 
 func (bridge *wasmBridge) writeScript(script string) {
-	if ProtocolInDebugLog {
-		DebugLog("Run script:")
-		DebugLog(script)
+	if protocolLogEnabled() {
+		protocolLog("Run script:")
+		protocolLog(script)
 	}
 
 	window := js.Global().Get("window")
@@ -43,7 +42,7 @@ func (bridge *wasmBridge) finishUpdateScript(htmlID string) {
 }
 
 func (bridge *wasmBridge) printFuncToLog(funcName string, args ...any) {
-	if ProtocolInDebugLog {
+	if protocolLogEnabled() {
 		text := funcName + "("
 		for i, arg := range args {
 			if i > 0 {
@@ -52,7 +51,7 @@ func (bridge *wasmBridge) printFuncToLog(funcName string, args ...any) {
 				text += fmt.Sprintf("`%v`", arg)
 			}
 		}
-		DebugLog(text + ")")
+		protocolLog(text + ")")
 	}
 }
 
@@ -68,8 +67,8 @@ func (bridge *wasmBridge) localStorageRequest(funcName string, args ...any) {
 }
 
 func (bridge *wasmBridge) updateInnerHTML(htmlID, html string) {
-	if ProtocolInDebugLog {
-		DebugLog(fmt.Sprintf("%s.innerHTML = '%s'", htmlID, html))
+	if protocolLogEnabled() {
+		protocolLog(fmt.Sprintf("%s.innerHTML = '%s'", htmlID, html))
 	}
 
 	element := js.Global().Get("document").Call("getElementById", htmlID)
@@ -80,8 +79,8 @@ func (bridge *wasmBridge) updateInnerHTML(htmlID, html string) {
 }
 
 func (bridge *wasmBridge) appendToInnerHTML(htmlID, html string) {
-	if ProtocolInDebugLog {
-		DebugLog(fmt.Sprintf("%s.innerHTML += '%s'", htmlID, html))
+	if protocolLogEnabled() {
+		protocolLog(fmt.Sprintf("%s.innerHTML += '%s'", htmlID, html))
 	}
 
 	element := js.Global().Get("document").Call("getElementById", htmlID)
@@ -93,8 +92,8 @@ func (bridge *wasmBridge) appendToInnerHTML(htmlID, html string) {
 }
 
 func (bridge *wasmBridge) updateCSSProperty(htmlID, property, value string) {
-	if ProtocolInDebugLog {
-		DebugLog(fmt.Sprintf("%s.style[%s] = '%s'", htmlID, property, value))
+	if protocolLogEnabled() {
+		protocolLog(fmt.Sprintf("%s.style[%s] = '%s'", htmlID, property, value))
 	}
 
 	element := js.Global().Get("document").Call("getElementById", htmlID)
@@ -118,9 +117,9 @@ func (bridge *wasmBridge) close() {
 
 /*
 func (bridge *wasmBridge) writeMessage(script string) bool {
-	if ProtocolInDebugLog {
-		DebugLog("Run script:")
-		DebugLog(script)
+	if protocolLogEnabled() {
+		protocolLog("Run script:")
+		protocolLog(script)
 	}
 
 	window := js.Global().Get("window")
@@ -130,29 +129,20 @@ func (bridge *wasmBridge) writeMessage(script string) bool {
 }
 */
 
-func (bridge *wasmBridge) prepareCSS(css string) string {
-	css = strings.ReplaceAll(css, `\t`, "\t")
-	css = strings.ReplaceAll(css, `\n`, "\n")
-	css = strings.ReplaceAll(css, `\'`, "'")
-	css = strings.ReplaceAll(css, `\"`, "\"")
-	css = strings.ReplaceAll(css, `\\`, "\\")
-	return css
-}
-
 func (bridge *wasmBridge) appendAnimationCSS(css string) {
 	styles := js.Global().Get("document").Call("getElementById", "ruiAnimations")
 	content := styles.Get("textContent").String()
-	styles.Set("textContent", content+"\n"+bridge.prepareCSS(css))
+	styles.Set("textContent", content+"\n"+unescapeScriptCSS(css))
 }
 
 func (bridge *wasmBridge) setAnimationCSS(css string) {
 	styles := js.Global().Get("document").Call("getElementById", "ruiAnimations")
-	styles.Set("textContent", bridge.prepareCSS(css))
+	styles.Set("textContent", unescapeScriptCSS(css))
 }
 
 func (bridge *wasmBridge) canvasStart(htmlID string) {
-	if ProtocolInDebugLog {
-		DebugLog("const ctx = document.getElementById('" + htmlID + "'elementId').getContext('2d');\nctx.save();")
+	if protocolLogEnabled() {
+		protocolLog("const ctx = document.getElementById('" + htmlID + "'elementId').getContext('2d');\nctx.save();")
 	}
 
 	bridge.canvas = js.Global().Call("getCanvasContext", htmlID)
@@ -222,8 +212,8 @@ func (bridge *wasmBridge) createPath2D(arg string) any {
 
 func (bridge *wasmBridge) updateCanvasProperty(property string, value any) {
 	if !bridge.canvas.IsNull() {
-		if ProtocolInDebugLog {
-			DebugLog(fmt.Sprintf("ctx.%s = '%v'", property, value))
+		if protocolLogEnabled() {
+			protocolLog(fmt.Sprintf("ctx.%s = '%v'", property, value))
 		}
 
 		bridge.canvas.Set(property, value)
@@ -303,5 +293,11 @@ func (bridge *wasmBridge) remoteAddr() string {
 	return "localhost"
 }
 
+func (bridge *wasmBridge) startBatch() {
+}
+
 func (bridge *wasmBridge) sendResponse() {
 }
+
+func (bridge *wasmBridge) finishBatch() {
+}
@@ -3,11 +3,15 @@
 package rui
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -18,11 +22,17 @@ type webBridge struct {
 	answerMutex         sync.Mutex
 	writeMutex          sync.Mutex
 	closed              bool
-	canvasBuffer        strings.Builder
+	canvasID            string
+	canvasCommands      []any
 	canvasVarNumber     int
-	updateScripts       map[string]*strings.Builder
+	updateCommands      map[string][]any
 	writeMessage        func(string) bool
 	callFuncImmediately func(funcName string, args ...any) bool
+	metrics             *appMetrics
+	batchMutex          sync.Mutex
+	batching            bool
+	batch               []string
+	batchSize           int
 }
 
 type wsBridge struct {
@@ -41,25 +51,41 @@ type canvasVar struct {
 	name string
 }
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 8096,
-}
+// maxBatchSize is the size of the batched messages after which the batch is sent without waiting for the end of the event
+const maxBatchSize = 256 << 10
+
+func createSocketBridge(w http.ResponseWriter, req *http.Request, checkOrigin func(*http.Request) bool,
+	readLimit int64, compressionLevel int) *wsBridge {
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:    1024,
+		WriteBufferSize:   8096,
+		CheckOrigin:       checkOrigin,
+		EnableCompression: compressionLevel >= 0,
+	}
 
-func createSocketBridge(w http.ResponseWriter, req *http.Request) *wsBridge {
-	upgrader.CheckOrigin = func(r *http.Request) bool { return true }
 	conn, err := upgrader.Upgrade(w, req, nil)
 	if err != nil {
 		ErrorLog(err.Error())
 		return nil
 	}
 
+	if readLimit > 0 {
+		conn.SetReadLimit(readLimit)
+	}
+
+	if compressionLevel > 0 {
+		if err := conn.SetCompressionLevel(compressionLevel); err != nil {
+			ErrorLog(err.Error())
+		}
+	}
+
 	bridge := new(wsBridge)
 	bridge.initBridge()
 	bridge.conn = conn
 	bridge.writeMessage = func(script string) bool {
-		if ProtocolInDebugLog {
-			DebugLog("🖥️ ← " + script)
+		if protocolLogEnabled() {
+			protocolLog("🖥️ ← " + script)
 		}
 
 		if bridge.conn == nil {
@@ -70,6 +96,7 @@ func createSocketBridge(w http.ResponseWriter, req *http.Request) *wsBridge {
 		bridge.writeMutex.Lock()
 		err := bridge.conn.WriteMessage(websocket.TextMessage, []byte(script))
 		bridge.writeMutex.Unlock()
+		bridge.metrics.messageSent(len(script))
 
 		if err != nil {
 			ErrorLog(err.Error())
@@ -77,7 +104,7 @@ func createSocketBridge(w http.ResponseWriter, req *http.Request) *wsBridge {
 		}
 		return true
 	}
-	bridge.callFuncImmediately = bridge.callFunc
+	bridge.callFuncImmediately = bridge.callImmediately
 	return bridge
 }
 
@@ -87,14 +114,15 @@ func createHttpBridge(req *http.Request) *httpBridge {
 	bridge.response = make(chan string, 100)
 	bridge.writeMessage = func(script string) bool {
 		if script != "" {
-			if ProtocolInDebugLog {
-				DebugLog(script)
+			if protocolLogEnabled() {
+				protocolLog(script)
 			}
 
 			if bridge.responseBuffer.Len() > 0 {
 				bridge.responseBuffer.WriteRune('\n')
 			}
 			bridge.responseBuffer.WriteString(script)
+			bridge.metrics.messageSent(len(script))
 		}
 		return true
 	}
@@ -107,120 +135,163 @@ func (bridge *webBridge) initBridge() {
 	bridge.answerID = 1
 	bridge.answer = make(map[int]chan DataObject)
 	bridge.closed = false
-	bridge.updateScripts = map[string]*strings.Builder{}
+	bridge.updateCommands = map[string][]any{}
 }
 
+// writeScript sends the script which is executed by eval on the client side. The library sends commands only,
+// the script is used by Session.RunScript which requires AppParams.AllowScripts
 func (bridge *webBridge) writeScript(script string) {
-	bridge.writeMessage(script)
+	bridge.send(jsonString(script))
+}
+
+// commandUnit returns the JSON array of the function name and the arguments. The function is called
+// by the command table of the client (see ruiCommands in app_scripts.js)
+func (bridge *webBridge) commandUnit(funcName string, args ...any) (string, bool) {
+	buffer := allocStringBuilder()
+	defer freeStringBuilder(buffer)
+
+	buffer.WriteRune('[')
+	buffer.WriteString(jsonString(funcName))
+	for _, arg := range args {
+		argText, ok := bridge.argToJSON(arg)
+		if !ok {
+			return "", false
+		}
+		buffer.WriteRune(',')
+		buffer.WriteString(argText)
+	}
+	buffer.WriteRune(']')
+	return buffer.String(), true
+}
+
+// frame joins the units into one message: the JSON array of commands
+func (bridge *webBridge) frame(units ...string) string {
+	return "[" + strings.Join(units, ",") + "]"
 }
 
+// send writes the unit to the client or appends it to the batch
+func (bridge *webBridge) send(unit string) bool {
+	bridge.batchMutex.Lock()
+	if bridge.batching {
+		bridge.batch = append(bridge.batch, unit)
+		bridge.batchSize += len(unit)
+		full := bridge.batchSize >= maxBatchSize
+		bridge.batchMutex.Unlock()
+
+		if full {
+			return bridge.flush()
+		}
+		return true
+	}
+	bridge.batchMutex.Unlock()
+
+	return bridge.writeMessage(bridge.frame(unit))
+}
+
+// startBatch starts collecting of the messages, they are sent in one message by sendResponse
+func (bridge *webBridge) startBatch() {
+	bridge.batchMutex.Lock()
+	bridge.batching = true
+	bridge.batchMutex.Unlock()
+}
+
+// flush sends the collected messages
+func (bridge *webBridge) flush() bool {
+	bridge.batchMutex.Lock()
+	units := bridge.batch
+	bridge.batch = nil
+	bridge.batchSize = 0
+	bridge.batchMutex.Unlock()
+
+	if len(units) == 0 {
+		return true
+	}
+	return bridge.writeMessage(bridge.frame(units...))
+}
+
+// finishBatch sends the collected messages and stops collecting
+func (bridge *webBridge) finishBatch() {
+	bridge.flush()
+	bridge.batchMutex.Lock()
+	bridge.batching = false
+	bridge.batchMutex.Unlock()
+}
+
+// startUpdateScript starts collecting of the updates of the element, they are sent
+// by finishUpdateScript as one "updateElement" command
 func (bridge *webBridge) startUpdateScript(htmlID string) bool {
-	if _, ok := bridge.updateScripts[htmlID]; ok {
+	if _, ok := bridge.updateCommands[htmlID]; ok {
 		return false
 	}
-	buffer := allocStringBuilder()
-	bridge.updateScripts[htmlID] = buffer
-	buffer.WriteString("{\nlet element = document.getElementById('")
-	buffer.WriteString(htmlID)
-	buffer.WriteString("');\nif (element) {\n")
+	bridge.updateCommands[htmlID] = []any{}
 	return true
 }
 
 func (bridge *webBridge) finishUpdateScript(htmlID string) {
-	if buffer, ok := bridge.updateScripts[htmlID]; ok {
-		buffer.WriteString("scanElementsSize();\n}\n}\n")
-		bridge.writeMessage(buffer.String())
-
-		freeStringBuilder(buffer)
-		delete(bridge.updateScripts, htmlID)
+	if commands, ok := bridge.updateCommands[htmlID]; ok {
+		delete(bridge.updateCommands, htmlID)
+		bridge.callFunc("updateElement", htmlID, commands)
 	}
 }
 
-func (bridge *webBridge) argToString(arg any) (string, bool) {
-	switch arg := arg.(type) {
-	case string:
-		escChars := []struct{ esc, repl string }{
-			{esc: "\\", repl: `\\`},
-			{esc: "'", repl: `\'`},
-			{esc: "\b", repl: `\b`},
-			{esc: "\t", repl: `\t`},
-			{esc: "\n", repl: `\n`},
-			{esc: "\v", repl: `\v`},
-			{esc: "\r", repl: `\r`},
-			{esc: "\f", repl: `\f`},
-		}
+// jsonString returns the JSON text of the string. Unlike json.Marshal, the HTML characters are not escaped
+func jsonString(text string) string {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(text)
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
 
-		for _, s := range escChars {
-			if strings.Contains(arg, s.esc) {
-				arg = strings.ReplaceAll(arg, s.esc, s.repl)
-			}
-		}
-
-		for n := range 0x20 {
-			esc := string([]rune{rune(n)})
-			if strings.Contains(arg, esc) {
-				repl := fmt.Sprintf(`\x%02d`, n)
-				arg = strings.ReplaceAll(arg, esc, repl)
-			}
+// argToJSON returns the JSON text of the argument of the function call
+func (bridge *webBridge) argToJSON(arg any) (string, bool) {
+	number := func(value float64) string {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return "null"
 		}
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
 
-		return `'` + arg + `'`, true
-
-	case rune:
-		switch arg {
-		case '\t':
-			return `'\t'`, true
-		case '\r':
-			return `'\r'`, true
-		case '\n':
-			return `'\n'`, true
-		case '\b':
-			return `'\b'`, true
-		case '\f':
-			return `'\f'`, true
-		case '\v':
-			return `'\v'`, true
-		case '\'':
-			return `'\''`, true
-		case '\\':
-			return `'\\'`, true
-		}
-		if arg < ' ' {
-			return fmt.Sprintf(`'\x%02d'`, int(arg)), true
-		}
-		return `'` + string(arg) + `'`, true
+	switch arg := arg.(type) {
+	case string:
+		return jsonString(arg), true
 
 	case bool:
 		if arg {
 			return "true", true
-		} else {
-			return "false", true
 		}
+		return "false", true
 
 	case float32:
-		return fmt.Sprintf("%g", float64(arg)), true
+		return number(float64(arg)), true
 
 	case float64:
-		return fmt.Sprintf("%g", arg), true
+		return number(arg), true
 
 	case []float64:
-		buffer := allocStringBuilder()
-		defer freeStringBuilder(buffer)
-		lead := '['
-		for _, val := range arg {
-			buffer.WriteRune(lead)
-			lead = ','
-			fmt.Fprintf(buffer, "%g", val)
+		items := make([]string, len(arg))
+		for i, value := range arg {
+			items[i] = number(value)
 		}
-		buffer.WriteRune(']')
-		return buffer.String(), true
+		return "[" + strings.Join(items, ",") + "]", true
+
+	case []any:
+		items := make([]string, len(arg))
+		for i, value := range arg {
+			item, ok := bridge.argToJSON(value)
+			if !ok {
+				return "", false
+			}
+			items[i] = item
+		}
+		return "[" + strings.Join(items, ",") + "]", true
 
 	case canvasVar:
-		return arg.name, true
+		return `{"var":` + jsonString(arg.name) + `}`, true
 
 	default:
 		if n, ok := isInt(arg); ok {
-			return fmt.Sprintf("%d", n), true
+			return strconv.Itoa(n), true
 		}
 	}
 
@@ -228,31 +299,9 @@ func (bridge *webBridge) argToString(arg any) (string, bool) {
 	return "", false
 }
 
-func (bridge *webBridge) callFuncScript(funcName string, args ...any) (string, bool) {
-	buffer := allocStringBuilder()
-	defer freeStringBuilder(buffer)
-
-	buffer.WriteString(funcName)
-	buffer.WriteRune('(')
-	for i, arg := range args {
-		argText, ok := bridge.argToString(arg)
-		if !ok {
-			return "", false
-		}
-
-		if i > 0 {
-			buffer.WriteString(", ")
-		}
-		buffer.WriteString(argText)
-	}
-	buffer.WriteString(");")
-
-	return buffer.String(), true
-}
-
 func (bridge *webBridge) callFunc(funcName string, args ...any) bool {
-	if funcText, ok := bridge.callFuncScript(funcName, args...); ok {
-		return bridge.writeMessage(funcText)
+	if unit, ok := bridge.commandUnit(funcName, args...); ok {
+		return bridge.send(unit)
 	}
 	return false
 }
@@ -270,168 +319,87 @@ func (bridge *webBridge) appendToInnerHTML(htmlID, html string) {
 }
 
 func (bridge *webBridge) updateCSSProperty(htmlID, property, value string) {
-	if buffer, ok := bridge.updateScripts[htmlID]; ok {
-		buffer.WriteString(`element.style['`)
-		buffer.WriteString(property)
-		buffer.WriteString(`'] = '`)
-		buffer.WriteString(value)
-		buffer.WriteString("';\n")
+	if commands, ok := bridge.updateCommands[htmlID]; ok {
+		bridge.updateCommands[htmlID] = append(commands, []any{"style", property, value})
 	} else {
 		bridge.callFunc("updateCSSProperty", htmlID, property, value)
 	}
 }
 
 func (bridge *webBridge) updateProperty(htmlID, property string, value any) {
-	if buffer, ok := bridge.updateScripts[htmlID]; ok {
-		if val, ok := bridge.argToString(value); ok {
-			buffer.WriteString(`element.setAttribute('`)
-			buffer.WriteString(property)
-			buffer.WriteString(`', `)
-			buffer.WriteString(val)
-			buffer.WriteString(");\n")
-		}
+	if commands, ok := bridge.updateCommands[htmlID]; ok {
+		bridge.updateCommands[htmlID] = append(commands, []any{"attr", property, value})
 	} else {
 		bridge.callFunc("updateProperty", htmlID, property, value)
 	}
 }
 
 func (bridge *webBridge) removeProperty(htmlID, property string) {
-	if buffer, ok := bridge.updateScripts[htmlID]; ok {
-		buffer.WriteString(`if (element.hasAttribute('`)
-		buffer.WriteString(property)
-		buffer.WriteString(`')) { element.removeAttribute('`)
-		buffer.WriteString(property)
-		buffer.WriteString("');}\n")
+	if commands, ok := bridge.updateCommands[htmlID]; ok {
+		bridge.updateCommands[htmlID] = append(commands, []any{"removeAttr", property})
 	} else {
 		bridge.callFunc("removeProperty", htmlID, property)
 	}
 }
 
 func (bridge *webBridge) appendAnimationCSS(css string) {
-	bridge.writeMessage(`{
-	let styles = document.getElementById('ruiAnimations');
-	if (styles) {
-		styles.textContent += '` + css + `';
-	}
-}`)
+	bridge.callFunc("appendAnimationCSS", unescapeScriptCSS(css))
 }
 
 func (bridge *webBridge) setAnimationCSS(css string) {
-	bridge.writeMessage(`{
-	let styles = document.getElementById('ruiAnimations');
-	if (styles) {
-		styles.textContent = '` + css + `';
-	}
-}`)
+	bridge.callFunc("setAnimationCSS", unescapeScriptCSS(css))
 }
 
+// canvasStart starts collecting of the drawing commands, they are sent by canvasFinish as one "drawCanvas" command
 func (bridge *webBridge) canvasStart(htmlID string) {
-	bridge.canvasBuffer.Reset()
-	bridge.canvasBuffer.WriteString("{\nconst ctx = getCanvasContext('")
-	bridge.canvasBuffer.WriteString(htmlID)
-	bridge.canvasBuffer.WriteString(`');`)
+	bridge.canvasID = htmlID
+	bridge.canvasCommands = []any{}
+}
+
+func (bridge *webBridge) addCanvasCommand(command ...any) {
+	bridge.canvasCommands = append(bridge.canvasCommands, command)
 }
 
 func (bridge *webBridge) callCanvasFunc(funcName string, args ...any) {
-	bridge.canvasBuffer.WriteString("\nctx.")
-	bridge.canvasBuffer.WriteString(funcName)
-	bridge.canvasBuffer.WriteRune('(')
-	for i, arg := range args {
-		if i > 0 {
-			bridge.canvasBuffer.WriteString(", ")
-		}
-		argText, _ := bridge.argToString(arg)
-		bridge.canvasBuffer.WriteString(argText)
-	}
-	bridge.canvasBuffer.WriteString(");")
+	bridge.addCanvasCommand(append([]any{"call", funcName}, args...)...)
 }
 
 func (bridge *webBridge) updateCanvasProperty(property string, value any) {
-	bridge.canvasBuffer.WriteString("\nctx.")
-	bridge.canvasBuffer.WriteString(property)
-	bridge.canvasBuffer.WriteString(" = ")
-	argText, _ := bridge.argToString(value)
-	bridge.canvasBuffer.WriteString(argText)
-	bridge.canvasBuffer.WriteString(";")
+	bridge.addCanvasCommand("set", property, value)
 }
 
 func (bridge *webBridge) createCanvasVar(funcName string, args ...any) any {
 	bridge.canvasVarNumber++
 	result := canvasVar{name: fmt.Sprintf("v%d", bridge.canvasVarNumber)}
-	bridge.canvasBuffer.WriteString("\nlet ")
-	bridge.canvasBuffer.WriteString(result.name)
-	bridge.canvasBuffer.WriteString(" = ctx.")
-	bridge.canvasBuffer.WriteString(funcName)
-	bridge.canvasBuffer.WriteRune('(')
-	for i, arg := range args {
-		if i > 0 {
-			bridge.canvasBuffer.WriteString(", ")
-		}
-		argText, _ := bridge.argToString(arg)
-		bridge.canvasBuffer.WriteString(argText)
-	}
-	bridge.canvasBuffer.WriteString(");")
+	bridge.addCanvasCommand(append([]any{"var", result.name, funcName}, args...)...)
 	return result
 }
 
 func (bridge *webBridge) createPath2D(arg string) any {
 	bridge.canvasVarNumber++
 	result := canvasVar{name: fmt.Sprintf("v%d", bridge.canvasVarNumber)}
-	bridge.canvasBuffer.WriteString("\nlet ")
-	bridge.canvasBuffer.WriteString(result.name)
-	bridge.canvasBuffer.WriteString(` = new Path2D(`)
 	if arg != "" {
-		argText, _ := bridge.argToString(arg)
-		bridge.canvasBuffer.WriteString(argText)
+		bridge.addCanvasCommand("path", result.name, arg)
+	} else {
+		bridge.addCanvasCommand("path", result.name)
 	}
-	bridge.canvasBuffer.WriteString(`);`)
 	return result
 }
 
 func (bridge *webBridge) callCanvasVarFunc(v any, funcName string, args ...any) {
-	varName, ok := v.(canvasVar)
-	if !ok {
-		return
-	}
-	bridge.canvasBuffer.WriteString("\n")
-	bridge.canvasBuffer.WriteString(varName.name)
-	bridge.canvasBuffer.WriteRune('.')
-	bridge.canvasBuffer.WriteString(funcName)
-	bridge.canvasBuffer.WriteRune('(')
-	for i, arg := range args {
-		if i > 0 {
-			bridge.canvasBuffer.WriteString(", ")
-		}
-		argText, _ := bridge.argToString(arg)
-		bridge.canvasBuffer.WriteString(argText)
+	if varName, ok := v.(canvasVar); ok {
+		bridge.addCanvasCommand(append([]any{"varCall", varName.name, funcName}, args...)...)
 	}
-	bridge.canvasBuffer.WriteString(");")
 }
 
 func (bridge *webBridge) callCanvasImageFunc(url string, property string, funcName string, args ...any) {
-
-	bridge.canvasBuffer.WriteString("\nimg = images.get('")
-	bridge.canvasBuffer.WriteString(url)
-	bridge.canvasBuffer.WriteString("');\nif (img) {\n")
-	if property != "" {
-		bridge.canvasBuffer.WriteString("ctx.")
-		bridge.canvasBuffer.WriteString(property)
-		bridge.canvasBuffer.WriteString(" = ")
-	}
-	bridge.canvasBuffer.WriteString("ctx.")
-	bridge.canvasBuffer.WriteString(funcName)
-	bridge.canvasBuffer.WriteString("(img")
-	for _, arg := range args {
-		bridge.canvasBuffer.WriteString(", ")
-		argText, _ := bridge.argToString(arg)
-		bridge.canvasBuffer.WriteString(argText)
-	}
-	bridge.canvasBuffer.WriteString(");\n}")
+	bridge.addCanvasCommand(append([]any{"image", url, property, funcName}, args...)...)
 }
 
 func (bridge *webBridge) canvasFinish() {
-	bridge.canvasBuffer.WriteString("\n}\n")
-	bridge.writeMessage(bridge.canvasBuffer.String())
+	commands := bridge.canvasCommands
+	bridge.canvasCommands = nil
+	bridge.callFunc("drawCanvas", bridge.canvasID, commands)
 }
 
 func (bridge *webBridge) remoteValue(funcName string, args ...any) (DataObject, bool) {
@@ -446,8 +414,10 @@ func (bridge *webBridge) remoteValue(funcName string, args ...any) (DataObject,
 	funcArgs := append([]any{answerID}, args...)
 	var result DataObject = nil
 
+	start := time.Now()
 	if bridge.callFuncImmediately(funcName, funcArgs...) {
 		result = <-answer
+		bridge.metrics.answerReceived(time.Since(start))
 	}
 
 	close(answer)
@@ -491,8 +461,21 @@ func (bridge *webBridge) answerReceived(answer DataObject) {
 
 func (bridge *wsBridge) close() {
 	bridge.closed = true
-	defer bridge.conn.Close()
-	bridge.conn = nil
+	if bridge.conn != nil {
+		defer bridge.conn.Close()
+		bridge.conn = nil
+	}
+}
+
+// closeWithStatus sends the close message with the status code and the reason to the client and closes the connection.
+// The client does not try to reconnect after such closing
+func (bridge *wsBridge) closeWithStatus(code int, reason string) {
+	if bridge.conn != nil {
+		bridge.writeMutex.Lock()
+		bridge.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+		bridge.writeMutex.Unlock()
+		bridge.close()
+	}
 }
 
 func (bridge *wsBridge) readMessage() (string, bool) {
@@ -508,6 +491,16 @@ func (bridge *wsBridge) readMessage() (string, bool) {
 }
 
 func (bridge *wsBridge) sendResponse() {
+	bridge.finishBatch()
+}
+
+// callImmediately sends the function call bypassing the batch (the messages collected before are sent first)
+func (bridge *wsBridge) callImmediately(funcName string, args ...any) bool {
+	if unit, ok := bridge.commandUnit(funcName, args...); ok {
+		bridge.flush()
+		return bridge.writeMessage(bridge.frame(unit))
+	}
+	return false
 }
 
 func (bridge *wsBridge) remoteAddr() string {
@@ -520,9 +513,10 @@ func (bridge *httpBridge) close() {
 }
 
 func (bridge *httpBridge) callImmediately(funcName string, args ...any) bool {
-	if funcText, ok := bridge.callFuncScript(funcName, args...); ok {
-		if ProtocolInDebugLog {
-			DebugLog("Run func: " + funcText)
+	if unit, ok := bridge.commandUnit(funcName, args...); ok {
+		funcText := bridge.frame(unit)
+		if protocolLogEnabled() {
+			protocolLog("Run func: " + funcText)
 		}
 		bridge.response <- funcText
 		return true
@@ -531,6 +525,7 @@ func (bridge *httpBridge) callImmediately(funcName string, args ...any) bool {
 }
 
 func (bridge *httpBridge) sendResponse() {
+	bridge.finishBatch()
 	bridge.writeMutex.Lock()
 	text := bridge.responseBuffer.String()
 	bridge.responseBuffer.Reset()
@@ -0,0 +1,126 @@
+package rui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCommandProtocol(t *testing.T) {
+	messages := []string{}
+	bridge := createHttpBridge(httptest.NewRequest(http.MethodPost, "/", nil))
+	bridge.writeMessage = func(text string) bool {
+		messages = append(messages, text)
+		return true
+	}
+
+	bridge.startBatch()
+	bridge.callFunc("updateInnerHTML", "id000001", "<b>'a'\n\"b\"</b>")
+	if bridge.startUpdateScript("id000002") {
+		bridge.updateCSSProperty("id000002", "width", "1.5px")
+		bridge.updateProperty("id000002", "title", "');alert('")
+		bridge.removeProperty("id000002", "hidden")
+		bridge.finishUpdateScript("id000002")
+	}
+	bridge.canvasStart("id000003")
+	gradient := bridge.createCanvasVar("createLinearGradient", 0, 0, 10, 10)
+	bridge.callCanvasVarFunc(gradient, "addColorStop", 0.5, "red")
+	bridge.updateCanvasProperty("fillStyle", gradient)
+	bridge.callCanvasFunc("setLineDash", []float64{1, 2})
+	bridge.canvasFinish()
+	bridge.appendAnimationCSS(`\n@keyframes a {\n\tfrom {\n}\n}\n`)
+	bridge.writeScript("scanElementsSize();")
+	bridge.finishBatch()
+
+	if len(messages) != 1 {
+		t.Fatalf("%d messages are sent, expected 1 batch", len(messages))
+	}
+
+	var commands []any
+	if err := json.Unmarshal([]byte(messages[0]), &commands); err != nil {
+		t.Fatalf("invalid message %s: %v", messages[0], err)
+	}
+
+	expected := []string{
+		`[updateInnerHTML id000001 <b>'a'` + "\n" + `"b"</b>]`,
+		`[updateElement id000002 [[style width 1.5px] [attr title ');alert('] [removeAttr hidden]]]`,
+		`[drawCanvas id000003 [[var v1 createLinearGradient 0 0 10 10] [varCall v1 addColorStop 0.5 red] [set fillStyle map[var:v1]] [call setLineDash [1 2]]]]`,
+		"[appendAnimationCSS \n@keyframes a {\n\tfrom {\n}\n}\n]",
+		"scanElementsSize();",
+	}
+	if len(commands) != len(expected) {
+		t.Fatalf("invalid message: %s", messages[0])
+	}
+	for i, command := range commands {
+		if text := fmt.Sprint(command); text != expected[i] {
+			t.Errorf("command %d: %s, expected: %s", i, text, expected[i])
+		}
+	}
+	if strings.Contains(messages[0], `\u003c`) {
+		t.Errorf("HTML is escaped in the message: %s", messages[0])
+	}
+
+	if text, ok := bridge.argToJSON(int32(65)); !ok || text != "65" {
+		t.Errorf("int32 argument: %s", text)
+	}
+
+	session := newSession(nil, 0, "", nil).(*sessionData)
+	session.bridge = bridge
+	messages = messages[:0]
+	session.RunScript("alert(1)")
+	if len(messages) != 0 {
+		t.Error("the script is sent without AppParams.AllowScripts")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if bridge := createSocketBridge(w, req, nil, 0, 9); bridge != nil {
+			bridge.callFunc("updateInnerHTML", "id000001", strings.Repeat("<p>text</p>", 100))
+			bridge.close()
+		}
+	}))
+	defer server.Close()
+
+	dialer := websocket.Dialer{EnableCompression: true}
+	conn, response, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if extensions := response.Header.Get("Sec-Websocket-Extensions"); !strings.Contains(extensions, "permessage-deflate") {
+		t.Errorf(`the compression is not negotiated, extensions = "%s"`, extensions)
+	}
+
+	if _, message, err := conn.ReadMessage(); err != nil {
+		t.Error(err)
+	} else if !strings.HasPrefix(string(message), `[["updateInnerHTML","id000001","<p>text</p>`) {
+		t.Errorf("invalid message: %.40s...", message)
+	}
+}
+
+func TestClientCommands(t *testing.T) {
+	start := strings.Index(defaultScripts, "const ruiCommands = {")
+	if start < 0 {
+		t.Fatal("the command table is not found")
+	}
+	end := strings.Index(defaultScripts[start:], "};")
+	if end < 0 {
+		t.Fatal("the end of the command table is not found")
+	}
+
+	table := defaultScripts[start+len("const ruiCommands = {") : start+end]
+	for _, name := range strings.Split(table, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.Contains(name, ":") {
+			continue
+		}
+		if !strings.Contains(defaultScripts, "\nfunction "+name+"(") {
+			t.Errorf(`the function of the "%s" command is not found`, name)
+		}
+	}
+}